@@ -14,6 +14,15 @@ type ConjugationCandidate struct {
 	Suffixes  []string
 	Infixes   []string
 	InsistPOS string
+	Trace     []TraceStep // only for ExplainDeconjugation
+}
+
+// The state of a single deconjugation.
+// Every call of deconjugate has its own, so words can be deconjugated in parallel.
+type deconjugation struct {
+	*Dictionary
+	candidates   []ConjugationCandidate
+	candidateMap map[string]ConjugationCandidate
 }
 
 func candidateDupe(candidate ConjugationCandidate) (c ConjugationCandidate) {
@@ -24,11 +33,10 @@ func candidateDupe(candidate ConjugationCandidate) (c ConjugationCandidate) {
 	a.Infixes = candidate.Infixes
 	a.Suffixes = candidate.Suffixes
 	a.InsistPOS = candidate.InsistPOS
+	a.Trace = candidate.Trace
 	return a
 }
 
-var candidates []ConjugationCandidate
-var candidateMap = map[string]ConjugationCandidate{}
 var unlenitionLetters = []string{
 	"ts", "kx", "tx", "px", // traps digraphs because they cannot unlenite
 	"f", "p", "h", "k", "s",
@@ -77,116 +85,30 @@ var lenition = map[string]string{
 	"'":  "",
 }
 
-var prefixes1Nouns = []string{"fì", "tsa", "fi"}
+// pe and fra have their own steps in deconjugateHelper
+var prefixes1Nouns = slotSpellings(PrefixAffix, SlotDeterminer, "pe", "fra")
 var prefixes1NounsLenition = []string{"pay", "fay"}
-var prefixes1lenition = []string{"pxe", "ay", "me"}
-var stemPrefixes = []string{"fne", "sna", "munsna"}
-var verbPrefixes = []string{"tsuk", "ketsuk"}
-var caseEndings = map[string]bool{
-	"ìl":  true,
-	"l":   true,
-	"it":  true,
-	"ti":  true,
-	"t":   true,
-	"ur":  true,
-	"ru":  true,
-	"r":   true,
-	"yä":  true,
-	"ä":   true,
-	"ìri": true,
-	"ri":  true,
-	"ye":  true,
-	"e":   true,
-	"il":  true,
-	"iri": true,
-}
+var prefixes1lenition = slotSpellings(PrefixAffix, SlotNumber)
+var stemPrefixes = slotSpellings(PrefixAffix, SlotStem)
+var verbPrefixes = slotSpellings(PrefixAffix, SlotVerb)
+var caseEndings = slotSpellingSet(SuffixAffix, SlotCase)
 
-var adposuffixes = []string{
-	// adpositions that can be mistaken for case endings
-	"pxel",                                                     //"agentive"
-	"mungwrr",                                                  //"dative"
-	"kxamlä", "ìlä", "wä", "nuä", "kxamle", "ìle", "we", "nue", //"genitive"
-	"ilä", "ile", //"genitive"
-	"teri", //"topical"
-	// Case endings
-	"ìl", "l", "it", "ti", "t", "ur", "ru", "r", "yä", "ä", "ìri", "ri", "ye", "e", "il", "iri",
-	// Sorted alphabetically by their reverse forms
-	"ftumfa", "nemfa", "rofa", "ka", "fa", "na", "ta", "ya", "yoa", "krrka", "ftuopa", //-a
-	"lisre", "pxisre", "sre", "luke", "ne", //-e
-	"fpi", "mi", //-i
-	"mì",                    //-ì
-	"lok",                   //-k
-	"mìkam", "kam", "mikam", //-m
-	"ken", "sìn", "talun", "sin", //-n
-	"äo", "eo", "io", "uo", "ro", "to", "sko", //-o
-	"tafkip", "takip", "fkip", "kip", //-p
-	"ftu", "hu", "sru", //-u
-	"pximaw", "maw", "pxaw", "few", "raw", //-w
-	"vay", "kay", //-y
-}
+// The adpositions that can be mistaken for case endings come first
+var adposuffixes = slices.Concat(slotSpellings(SuffixAffix, SlotAdposition), slotSpellings(SuffixAffix, SlotCase))
 
-var vowelSuffixes = map[string][]string{
-	"äo":  []string{"ä", "e"},
-	"eo":  []string{"e"},
-	"io":  []string{"i"},
-	"uo":  []string{"u"},
-	"ìlä": []string{"ì"},
-	"o":   []string{"o"},
-}
-var stemSuffixes = []string{"tsyìp", "tsyip", "fkeyk"}
-var verbSuffixes = []string{"tswo", "yu", "tseng"}
-
-var infixes = map[rune][]string{
-	rune('a'): {"ay", "asy", "aly", "ary", "am", "alm", "arm", "ats", "awn", "ap", "ang"},
-	rune('ä'): {"äng", "äpeyk", "äp"},
-	rune('e'): {"epeyk", "ep", "eng", "er", "ei", "eiy", "eyk"},
-	rune('i'): {"iy", "isy", "ily", "iry", "im", "ilm", "irm", "iyev", "iv", "ilv", "irv", "imv"},
-	rune('ì'): {"ìy", "ìsy", "ìly", "ìry", "ìm", "ìlm", "ìrm", "ìyev"},
-	rune('o'): {"ol"},
-	rune('u'): {"us", "uy"},
-}
+var vowelSuffixes = hyphenSuffixes()
+var stemSuffixes = slotSpellings(SuffixAffix, SlotStem)
+var verbSuffixes = slotSpellings(SuffixAffix, SlotVerb)
 
-var prefirst = []string{"äp", "äpeyk", "eyk", "epeyk", "ep"}
-var first = []string{"ay", "asy", "aly", "ary", "ìy", "iy", "ìsy", "ìly", "ìry", "ol", "er", "ìm", "im",
-	"ìlm", "ìrm", "am", "alm", "arm", "ìyev", "iyev", "iv", "ilv", "irv", "imv", "us", "awn", "isy", "ily", "iry", "ilm", "irm"}
-var second = []string{"ei", "eiy", "äng", "eng", "ang", "uy", "ats"}
-
-var prefirstMap = map[string]bool{"äp": true, "äpeyk": true, "eyk": true, "ep": true, "epeyk": true}
-var firstMap = map[string]bool{"ay": true, "asy": true, "aly": true, "ary": true, "ìy": true, "iy": true, "ìsy": true,
-	"ìly": true, "ìry": true, "ol": true, "er": true, "ìm": true, "im": true, "ìlm": true,
-	"ìrm": true, "am": true, "alm": true, "arm": true, "ìyev": true, "iyev": true,
-	"iv": true, "ilv": true, "irv": true, "imv": true, "us": true, "awn": true, "isy": true, "ily": true, "iry": true, "ilm": true, "irm": true}
-var secondMap = map[string]bool{"ei": true, "eiy": true, "äng": true, "uy": true, "ats": true, "ap": true, "ang": true, "eng": true}
-
-var unreefFixes = map[string]string{
-	"eng":    "äng",
-	"epeyk":  "äpeyk",
-	"ep":     "äp",
-	"ye":     "yä",
-	"e":      "ä",
-	"we":     "wä",
-	"ìle":    "ìlä",
-	"nue":    "nuä",
-	"kxamle": "kxamlä",
-}
+var infixes = infixesByVowel()
 
-var unstrictFixes = map[string]string{
-	// Below this are for diacritic independence of i and ì
-	"fi":    "fì",
-	"ilä":   "ìlä",
-	"mi":    "mì",
-	"mikam": "mìkam",
-	"sin":   "sìn",
-	"il":    "ìl",
-	"iri":   "ìri",
-	"ap":    "äp",
-	"ang":   "äng",
-	"iy":    "ìy",
-	"im":    "ìm",
-	"ilm":   "ìlm",
-	"isy":   "ìsy",
-	"tsyip": "tsyìp",
-}
+var prefirstMap = slotSpellingSet(InfixAffix, SlotPrefirst)
+var firstMap = slotSpellingSet(InfixAffix, SlotFirst)
+var secondMap = slotSpellingSet(InfixAffix, SlotSecond)
+
+// The reef and loose spellings of the affixes, and the forms they stand for
+var unreefFixes = catalogSpellings(true)
+var unstrictFixes = catalogSpellings(false)
 
 var weirdNounSuffixes = map[string]string{
 	// For "tsa" with case endings
@@ -315,8 +237,8 @@ var productiveCompounds = map[string][][]string{
 	"tseyä":          forbiddenTsaw,
 }
 
-func isDuplicate(input ConjugationCandidate) bool {
-	if a, ok := candidateMap[input.Word]; ok {
+func (d *deconjugation) isDuplicate(input ConjugationCandidate) bool {
+	if a, ok := d.candidateMap[input.Word]; ok {
 		if input.InsistPOS == a.InsistPOS {
 			if len(input.Prefixes) == len(a.Prefixes) && len(input.Suffixes) == len(a.Suffixes) {
 				if len(input.Infixes) == len(a.Infixes) {
@@ -328,25 +250,34 @@ func isDuplicate(input ConjugationCandidate) bool {
 	return false
 }
 
-func isDuplicateFix(fixes []string, fix string, strict bool, allowReef bool) (newFixes []string, added bool) {
+// Read a reef or loose spelling as the affix it stands for, with the steps from one to the other.
+// ok is false if the spelling isn't allowed in the mode.
+func readFix(spelling string, strict bool, allowReef bool) (fix string, steps []TraceStep, ok bool) {
+	fix = spelling
 	if newfix, ok := unreefFixes[fix]; ok {
 		if !allowReef {
-			return fixes, false
+			return fix, nil, false
 		}
+		steps = append(steps, TraceStep{TraceUnreef, "", fix, newfix})
 		fix = newfix
 	}
 	if newfix, ok := unstrictFixes[fix]; ok {
 		if strict {
-			return fixes, false
+			return fix, nil, false
 		}
+		steps = append(steps, TraceStep{TraceUnstrict, "", fix, newfix})
 		fix = newfix
 	}
-	if fix == "ile" {
-		if !strict && allowReef {
-			fix = "ìlä"
-		} else {
-			return fixes, false
-		}
+	for i := range steps {
+		steps[i].Affix = fix
+	}
+	return fix, steps, true
+}
+
+func isDuplicateFix(fixes []string, fix string, strict bool, allowReef bool) (newFixes []string, added bool) {
+	fix, _, ok := readFix(fix, strict, allowReef)
+	if !ok {
+		return fixes, false
 	}
 	for _, a := range fixes {
 		if fix == a {
@@ -360,21 +291,11 @@ func isDuplicateFix(fixes []string, fix string, strict bool, allowReef bool) (ne
 func infixError(query string, didYouMean string, ipa string) Word {
 	d := Word{}
 	d.Navi = query
-	d.EN = "Did you mean **" + didYouMean + "**?" // English
 	// TODO: Translations
-	d.DE = d.EN // German (Deutsch)
-	d.ES = d.EN // Spanish (Español)
-	d.ET = d.EN // Estonian (Eesti)
-	d.FR = d.EN // French (Français)
-	d.HU = d.EN // Hungarian (Magyar)
-	d.KO = d.EN // Korean (한국어)
-	d.NL = d.EN // Dutch (Nederlands)
-	d.PL = d.EN // Polish (Polski)
-	d.PT = d.EN // Portuguese (Português)
-	d.RU = d.EN // Russian (Русский)
-	d.SV = d.EN // Swedish (Svenska)
-	d.TR = d.EN // Turkish (Türkçe)
-	d.UK = d.EN // Ukrainian (Українська)
+	d.Definitions = map[string]string{}
+	for _, code := range LanguageCodes() {
+		d.Definitions[code] = "Did you mean **" + didYouMean + "**?"
+	}
 	d.IPA = ipa
 	d.PartOfSpeech = "err."
 	return d
@@ -531,10 +452,10 @@ func verifyCaseEnding(noun string, ending string) bool {
 	return false
 }
 
-func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck int, unlenite int8,
+func (d *deconjugation) deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck int, unlenite int8,
 	infix []string, lastPrefix string, lastSuffix string, strict bool, allowReef bool) []ConjugationCandidate {
-	if isDuplicate(input) {
-		return candidates
+	if d.isDuplicate(input) {
+		return d.candidates
 	}
 
 	vowels := "aäeiìouù"
@@ -544,10 +465,12 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 	if allowReef {
 		// fneu checking for fne-'u
 		if len(lastPrefix) > 0 && len(input.Word) > 0 && hasAt(vowels, lastPrefix, -1) && hasAt(vowels, input.Word, 0) {
-			if !implContainsAny(prefixes1lenition, []string{lastPrefix}) { // do not do this for leniting prefixes
+			// do not do this for leniting prefixes, the lenition of ' is undone with them
+			if !implContainsAny(prefixes1lenition, []string{lastPrefix}) && lastPrefix != "pe" {
 				newCandidate := candidateDupe(input)
 				newCandidate.Word = "'" + newCandidate.Word
-				deconjugateHelper(newCandidate, prefixCheck, suffixCheck, unlenite, infix, "", "", strict, allowReef)
+				newCandidate.trace(TraceRespell, "", input.Word, newCandidate.Word)
+				d.deconjugateHelper(newCandidate, prefixCheck, suffixCheck, unlenite, infix, "", "", strict, allowReef)
 			}
 		}
 
@@ -555,19 +478,21 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 		if len(lastSuffix) > 0 && len(input.Word) > 0 && hasAt(vowels, lastSuffix, 0) && hasAt(vowels, input.Word, -1) {
 			newCandidate := candidateDupe(input)
 			newCandidate.Word += "'"
-			deconjugateHelper(newCandidate, prefixCheck, suffixCheck, unlenite, infix, "", "", strict, allowReef)
+			newCandidate.trace(TraceRespell, "", input.Word, newCandidate.Word)
+			d.deconjugateHelper(newCandidate, prefixCheck, suffixCheck, unlenite, infix, "", "", strict, allowReef)
 		}
 	}
 
 	// Exceptions for how words conjugate
 	if len(input.Suffixes) == 1 {
 		if validWord, ok := weirdNounSuffixes[input.Word]; ok {
+			input.trace(TraceIrregular, "", input.Word, validWord)
 			input.Word = validWord
-			if !isDuplicate(input) {
-				candidates = append(candidates, input)
-				candidateMap[input.Word] = input
+			if !d.isDuplicate(input) {
+				d.candidates = append(d.candidates, input)
+				d.candidateMap[input.Word] = input
 			}
-			return candidates
+			return d.candidates
 		}
 	}
 
@@ -575,17 +500,18 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 		// for the cases of zen<ats>eke and zen<uy>eke
 		// confirmed in here: https://forum.learnnavi.org/index.php?msg=493217
 		if input.Word == "zeneke" {
+			input.trace(TraceIrregular, "", input.Word, "zenke")
 			input.Word = "zenke"
-			if !isDuplicate(input) {
-				candidates = append(candidates, input)
-				candidateMap[input.Word] = input
+			if !d.isDuplicate(input) {
+				d.candidates = append(d.candidates, input)
+				d.candidateMap[input.Word] = input
 			}
-			return candidates
+			return d.candidates
 		}
 	}
 
-	candidates = append(candidates, input)
-	candidateMap[input.Word] = input
+	d.candidates = append(d.candidates, input)
+	d.candidateMap[input.Word] = input
 
 	// Add a way for e to become ä again if we're down to 1 syllable
 	if !strict && allowReef && len([]rune(input.Word)) < 8 && (len(input.Prefixes) > 0 ||
@@ -593,7 +519,8 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 		// could be tskxäpx (7 letters 1 syllable)
 		newCandidate := candidateDupe(input)
 		newCandidate.Word = strings.ReplaceAll(newCandidate.Word, "e", "ä")
-		deconjugateHelper(newCandidate, prefixCheck, suffixCheck, unlenite, infix, "", "", strict, allowReef)
+		newCandidate.trace(TraceUnreef, "", input.Word, newCandidate.Word)
+		d.deconjugateHelper(newCandidate, prefixCheck, suffixCheck, unlenite, infix, "", "", strict, allowReef)
 	}
 
 	newString := ""
@@ -605,9 +532,10 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 			newCandidate.Word = strings.TrimSuffix(input.Word, "tswo") + " si"
 			newCandidate.InsistPOS = "v."
 			newCandidate.Suffixes, added = isDuplicateFix(newCandidate.Suffixes, "tswo", strict, allowReef)
-			if added && !isDuplicate(newCandidate) {
-				candidates = append(candidates, newCandidate)
-				candidateMap[input.Word] = input
+			if added && !d.isDuplicate(newCandidate) {
+				newCandidate.traceFix(TraceSuffix, "tswo", input.Word, strict, allowReef)
+				d.candidates = append(d.candidates, newCandidate)
+				d.candidateMap[input.Word] = input
 			}
 		}
 	}
@@ -624,7 +552,7 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 			}
 
 			if strict {
-				for _, pairWordSet := range multiword_words[trimmedWord] {
+				for _, pairWordSet := range d.multiwords[trimmedWord] {
 					for _, pairWord := range pairWordSet {
 						if pairWord == "si" {
 							found = true
@@ -636,7 +564,7 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 					}
 				}
 			} else {
-				for _, pairWordSet := range multiword_words_loose[trimmedWord] {
+				for _, pairWordSet := range d.multiwordsLoose[trimmedWord] {
 					for _, pairWord := range pairWordSet {
 						if pairWord == "si" {
 							found = true
@@ -653,7 +581,7 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				noA := strings.TrimPrefix(trimmedWord, "a")
 
 				if strict {
-					for _, pairWordSet := range multiword_words[noA] {
+					for _, pairWordSet := range d.multiwords[noA] {
 						for _, pairWord := range pairWordSet {
 							if pairWord == "si" {
 								found = true
@@ -666,7 +594,7 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 						}
 					}
 				} else {
-					for _, pairWordSet := range multiword_words_loose[noA] {
+					for _, pairWordSet := range d.multiwordsLoose[noA] {
 						for _, pairWord := range pairWordSet {
 							if pairWord == "si" {
 								found = true
@@ -681,9 +609,9 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				}
 			}
 
-			if !isDuplicate(input) {
-				candidates = append(candidates, input)
-				candidateMap[input.Word] = input
+			if !d.isDuplicate(input) {
+				d.candidates = append(d.candidates, input)
+				d.candidateMap[input.Word] = input
 			} // to bump the real candidate into recognition
 
 			if found {
@@ -698,12 +626,21 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				if aPosition == 1 {
 					newCandidate.Suffixes = append(newCandidate.Suffixes, "a")
 				}
-				if !isDuplicate(newCandidate) {
-					candidates = append(candidates, newCandidate)
-					candidateMap[input.Word] = input
+				if !d.isDuplicate(newCandidate) {
+					word := input.Word
+					if aPosition == 1 {
+						newCandidate.trace(TraceSuffix, "a", word, strings.TrimSuffix(word, "a"))
+						word = strings.TrimSuffix(word, "a")
+					}
+					newCandidate.trace(TraceInfix, "us", word, trimmedWord+" si")
+					if aPosition == -1 {
+						newCandidate.trace(TracePrefix, "a", trimmedWord+" si", newCandidate.Word)
+					}
+					d.candidates = append(d.candidates, newCandidate)
+					d.candidateMap[input.Word] = input
 				}
 			}
-			return candidates
+			return d.candidates
 		}
 	}
 
@@ -721,28 +658,32 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 			newCandidate.Word = input.Word[1:]
 			newCandidate.Prefixes, added = isDuplicateFix(newCandidate.Prefixes, "a", strict, allowReef)
 			if added {
+				newCandidate.traceFix(TracePrefix, "a", input.Word, strict, allowReef)
 				newCandidate.InsistPOS = "adj."
-				deconjugateHelper(newCandidate, 1, suffixCheck, -1, []string{}, "a", "", strict, allowReef)
+				d.deconjugateHelper(newCandidate, 1, suffixCheck, -1, []string{}, "a", "", strict, allowReef)
 				newCandidate.InsistPOS = "v."
-				deconjugateHelper(newCandidate, 1, suffixCheck, -1, []string{"", "", ""}, "a", "", strict, allowReef)
+				d.deconjugateHelper(newCandidate, 1, suffixCheck, -1, []string{"", "", ""}, "a", "", strict, allowReef)
 			}
 		} else if strings.HasPrefix(input.Word, "nì") {
 			newCandidate := candidateDupe(input)
 			newCandidate.Word = strings.TrimPrefix(input.Word, "nì")
 			newCandidate.Prefixes, added = isDuplicateFix(newCandidate.Prefixes, "nì", strict, allowReef)
 			if added {
+				newCandidate.traceFix(TracePrefix, "nì", input.Word, strict, allowReef)
 				newCandidate.InsistPOS = "nì."
 				// No other affixes allowed
-				deconjugateHelper(newCandidate, 10, 10, -1, []string{}, "nì", "", strict, allowReef) // No other fixes
+				d.deconjugateHelper(newCandidate, 10, 10, -1, []string{}, "nì", "", strict, allowReef) // No other fixes
 			}
 		} else if !strict && strings.HasPrefix(input.Word, "ni") {
 			newCandidate := candidateDupe(input)
 			newCandidate.Word = strings.TrimPrefix(input.Word, "ni")
 			newCandidate.Prefixes, added = isDuplicateFix(newCandidate.Prefixes, "nì", strict, allowReef)
 			if added {
+				newCandidate.trace(TraceUnstrict, "nì", "ni", "nì")
+				newCandidate.trace(TracePrefix, "nì", input.Word, newCandidate.Word)
 				newCandidate.InsistPOS = "nì."
 				// No other affixes allowed
-				deconjugateHelper(newCandidate, 10, 10, -1, []string{}, "nì", "", strict, allowReef) // No other fixes
+				d.deconjugateHelper(newCandidate, 10, 10, -1, []string{}, "nì", "", strict, allowReef) // No other fixes
 			}
 		}
 		fallthrough
@@ -758,11 +699,13 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				if !added {
 					continue
 				}
-				deconjugateHelper(newCandidate, 10, 10, -1, []string{}, element, "", strict, allowReef)
+				newCandidate.traceFix(TracePrefix, element, input.Word, strict, allowReef)
+				d.deconjugateHelper(newCandidate, 10, 10, -1, []string{}, element, "", strict, allowReef)
 
 				// check "tsatan", "tan" and "atan"
 				newCandidate.Word = string(get_last_rune(element, 1)) + newCandidate.Word
-				deconjugateHelper(newCandidate, 10, 10, -1, []string{}, element, "", strict, allowReef)
+				newCandidate.trace(TraceRespell, "", strings.TrimPrefix(input.Word, element), newCandidate.Word)
+				d.deconjugateHelper(newCandidate, 10, 10, -1, []string{}, element, "", strict, allowReef)
 			}
 		}
 		fallthrough
@@ -782,11 +725,13 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 					if !added {
 						continue
 					}
-					deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+					newCandidate.traceFix(TracePrefix, element, input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 
 					// check "tsatan", "tan" and "atan"
 					newCandidate.Word = string(get_last_rune(element, 1)) + newString
-					deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+					newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+					d.deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 				}
 			}
 		}
@@ -805,38 +750,45 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 					continue
 				}
 				newCandidate.InsistPOS = "n."
+				newCandidate.traceFix(TracePrefix, element, input.Word, strict, allowReef)
+				prefixed := newCandidate.Trace
 
 				// Could it be pekoyu (pe + 'ekoyu, not pe + kxoyu)
 				if hasAt(vowels, element, -1) {
 					// check "pxeyktan", "yktan" and "eyktan"
 					newCandidate.Word = string(get_last_rune(element, 1)) + newString
-					deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+					newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+					d.deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 
 					// check "pxeylan", "ylan" and "'eylan"
 					newCandidate.Word = "'" + newCandidate.Word
-					deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+					newCandidate.trace(TraceLenition, "'"+string(get_last_rune(element, 1))+"→"+string(get_last_rune(element, 1)), newCandidate.Word[1:], newCandidate.Word)
+					d.deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 				}
 
 				// find out the possible unlenited forms
 				for _, oldPrefix := range unlenitionLetters {
 					// If it has a letter that could have changed for lenition,
 					if strings.HasPrefix(newString, oldPrefix) {
-						// put all possibilities in the candidates
+						// put all possibilities in the d.candidates
 						lenited = true
 
 						for _, newPrefix := range unlenition[oldPrefix] {
 							newCandidate.Word = newPrefix + strings.TrimPrefix(newString, oldPrefix)
+							newCandidate.Trace = prefixed
 							if oldPrefix != newPrefix {
 								newCandidate.Lenition = []string{newPrefix + "→" + oldPrefix}
+								newCandidate.trace(TraceLenition, newCandidate.Lenition[0], newString, newCandidate.Word)
 							}
-							deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, oldPrefix, "", strict, allowReef)
+							d.deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 						}
 						break // We don't want the "ts" to become "txs"
 					}
 				}
 				if !lenited {
 					newCandidate.Word = newString
-					deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+					newCandidate.Trace = prefixed
+					d.deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 				}
 			}
 		}
@@ -852,38 +804,45 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				newCandidate.Prefixes, added = isDuplicateFix(newCandidate.Prefixes, "pe", strict, allowReef)
 				if added {
 					newCandidate.InsistPOS = "n."
+					newCandidate.traceFix(TracePrefix, "pe", input.Word, strict, allowReef)
+					prefixed := newCandidate.Trace
 
 					// Could it be pekoyu (pe + 'ekoyu, not pe + kxoyu)
 					if hasAt(vowels, "pe", -1) {
 						// check "pxeyktan", "yktan" and "eyktan"
 						newCandidate.Word = string(get_last_rune("pe", 1)) + newString
-						deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, "pe", "", strict, allowReef)
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+						d.deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, "pe", "", strict, allowReef)
 
 						// check "pxeylan", "ylan" and "'eylan"
 						newCandidate.Word = "'" + newCandidate.Word
-						deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, "pe", "", strict, allowReef)
+						newCandidate.trace(TraceLenition, "'"+string(get_last_rune("pe", 1))+"→"+string(get_last_rune("pe", 1)), newCandidate.Word[1:], newCandidate.Word)
+						d.deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, "pe", "", strict, allowReef)
 					}
 
 					// find out the possible unlenited forms
 					for _, oldPrefix := range unlenitionLetters {
 						// If it has a letter that could have changed for lenition,
 						if strings.HasPrefix(newString, oldPrefix) {
-							// put all possibilities in the candidates
+							// put all possibilities in the d.candidates
 							lenited = true
 
 							for _, newPrefix := range unlenition[oldPrefix] {
 								newCandidate.Word = newPrefix + strings.TrimPrefix(newString, oldPrefix)
+								newCandidate.Trace = prefixed
 								if oldPrefix != newPrefix {
 									newCandidate.Lenition = []string{newPrefix + "→" + oldPrefix}
+									newCandidate.trace(TraceLenition, newCandidate.Lenition[0], newString, newCandidate.Word)
 								}
-								deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, oldPrefix, "", strict, allowReef)
+								d.deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, "pe", "", strict, allowReef)
 							}
 							break // We don't want the "ts" to become "txs"
 						}
 					}
 					if !lenited {
 						newCandidate.Word = newString
-						deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, "pe", "", strict, allowReef)
+						newCandidate.Trace = prefixed
+						d.deconjugateHelper(newCandidate, 3, suffixCheck, -1, []string{}, "pe", "", strict, allowReef)
 					}
 				}
 			}
@@ -902,11 +861,13 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				newCandidate.InsistPOS = "n."
 				newCandidate.Prefixes, added = isDuplicateFix(newCandidate.Prefixes, "fra", strict, allowReef)
 				if added {
-					deconjugateHelper(newCandidate, 4, suffixCheck, -1, []string{}, "fra", "", strict, allowReef)
+					newCandidate.traceFix(TracePrefix, "fra", input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, 4, suffixCheck, -1, []string{}, "fra", "", strict, allowReef)
 
 					// check "tsatan", "tan" and "atan"
 					newCandidate.Word = string(get_last_rune("fra", 1)) + newString
-					deconjugateHelper(newCandidate, 4, suffixCheck, -1, []string{}, "fra", "", strict, allowReef)
+					newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+					d.deconjugateHelper(newCandidate, 4, suffixCheck, -1, []string{}, "fra", "", strict, allowReef)
 				}
 			}
 		}
@@ -927,38 +888,45 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 						continue
 					}
 					newCandidate.InsistPOS = "n."
+					newCandidate.traceFix(TracePrefix, element, input.Word, strict, allowReef)
+					prefixed := newCandidate.Trace
 
 					// Could it be pekoyu (pe + 'ekoyu, not pe + kxoyu)
 					if hasAt(vowels, element, -1) {
 						// check "pxeyktan", "yktan" and "eyktan"
 						newCandidate.Word = string(get_last_rune(element, 1)) + newString
-						deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+						d.deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 
 						// check "pxeylan", "ylan" and "'eylan"
 						newCandidate.Word = "'" + newCandidate.Word
-						deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+						newCandidate.trace(TraceLenition, "'"+string(get_last_rune(element, 1))+"→"+string(get_last_rune(element, 1)), newCandidate.Word[1:], newCandidate.Word)
+						d.deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 					}
 
 					// find out the possible unlenited forms
 					for _, oldPrefix := range unlenitionLetters {
 						// If it has a letter that could have changed for lenition,
 						if strings.HasPrefix(newString, oldPrefix) {
-							// put all possibilities in the candidates
+							// put all possibilities in the d.candidates
 							lenited = true
 
 							for _, newPrefix := range unlenition[oldPrefix] {
 								newCandidate.Word = newPrefix + strings.TrimPrefix(newString, oldPrefix)
+								newCandidate.Trace = prefixed
 								if oldPrefix != newPrefix {
 									newCandidate.Lenition = []string{newPrefix + "→" + oldPrefix}
+									newCandidate.trace(TraceLenition, newCandidate.Lenition[0], newString, newCandidate.Word)
 								}
-								deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, oldPrefix, "", strict, allowReef)
+								d.deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 							}
 							break // We don't want the "ts" to become "txs"
 						}
 					}
 					if !lenited {
 						newCandidate.Word = newString
-						deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+						newCandidate.Trace = prefixed
+						d.deconjugateHelper(newCandidate, 5, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 					}
 				}
 			}
@@ -977,11 +945,13 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 					if !added {
 						continue
 					}
-					deconjugateHelper(newCandidate, 6, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+					newCandidate.traceFix(TracePrefix, element, input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, 6, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 
 					// check "tsatan", "tan" and "atan"
 					newCandidate.Word = string(get_last_rune(element, 1)) + newCandidate.Word
-					deconjugateHelper(newCandidate, 6, suffixCheck, -1, []string{}, element, "", strict, allowReef)
+					newCandidate.trace(TraceRespell, "", strings.TrimPrefix(input.Word, element), newCandidate.Word)
+					d.deconjugateHelper(newCandidate, 6, suffixCheck, -1, []string{}, element, "", strict, allowReef)
 				}
 			}
 		}
@@ -995,10 +965,12 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				newCandidate.InsistPOS = "v."
 				newCandidate.Prefixes, added = isDuplicateFix(newCandidate.Prefixes, "tì", strict, allowReef)
 				if added {
-					deconjugateHelper(newCandidate, 10, 10, -1, []string{"", "", ""}, "tì", "", strict, allowReef) // No other prefixes allowed
+					newCandidate.traceFix(TracePrefix, "tì", input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, 10, 10, -1, []string{"", "", ""}, "tì", "", strict, allowReef) // No other prefixes allowed
 
 					newCandidate.Word = "ì" + newCandidate.Word
-					deconjugateHelper(newCandidate, 10, 10, -1, []string{"", "", ""}, "tì", "", strict, allowReef) // Or any additional suffixes
+					newCandidate.trace(TraceRespell, "", strings.TrimPrefix(input.Word, "tì"), newCandidate.Word)
+					d.deconjugateHelper(newCandidate, 10, 10, -1, []string{"", "", ""}, "tì", "", strict, allowReef) // Or any additional suffixes
 				}
 			}
 		} else if !strict && strings.HasPrefix(input.Word, "ti") {
@@ -1009,10 +981,13 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				newCandidate.InsistPOS = "v."
 				newCandidate.Prefixes, added = isDuplicateFix(newCandidate.Prefixes, "tì", strict, allowReef)
 				if added {
-					deconjugateHelper(newCandidate, 10, 10, -1, []string{"", "", ""}, "tì", "", strict, allowReef) // No other prefixes allowed
+					newCandidate.trace(TraceUnstrict, "tì", "ti", "tì")
+					newCandidate.trace(TracePrefix, "tì", input.Word, newCandidate.Word)
+					d.deconjugateHelper(newCandidate, 10, 10, -1, []string{"", "", ""}, "tì", "", strict, allowReef) // No other prefixes allowed
 
 					newCandidate.Word = "ì" + newCandidate.Word
-					deconjugateHelper(newCandidate, 10, 10, -1, []string{"", "", ""}, "tì", "", strict, allowReef) // Or any additional suffixes
+					newCandidate.trace(TraceRespell, "", strings.TrimPrefix(input.Word, "ti"), newCandidate.Word)
+					d.deconjugateHelper(newCandidate, 10, 10, -1, []string{"", "", ""}, "tì", "", strict, allowReef) // Or any additional suffixes
 				}
 			}
 		}
@@ -1025,12 +1000,15 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 			newCandidate := candidateDupe(input)
 			newCandidate.Word = strings.TrimSuffix(newCandidate.Word, "sì")
 			newCandidate.Suffixes = append(newCandidate.Suffixes, "sì")
-			deconjugateHelper(newCandidate, newPrefixCheck, 1, unlenite, infix, "", "sì", strict, allowReef)
+			newCandidate.trace(TraceSuffix, "sì", input.Word, newCandidate.Word)
+			d.deconjugateHelper(newCandidate, newPrefixCheck, 1, unlenite, infix, "", "sì", strict, allowReef)
 		} else if !strict && len(input.Suffixes) == 0 && strings.HasSuffix(input.Word, "si") {
 			newCandidate := candidateDupe(input)
 			newCandidate.Word = strings.TrimSuffix(newCandidate.Word, "si")
 			newCandidate.Suffixes = append(newCandidate.Suffixes, "sì")
-			deconjugateHelper(newCandidate, newPrefixCheck, 1, unlenite, infix, "", "sì", strict, allowReef)
+			newCandidate.trace(TraceUnstrict, "sì", "si", "sì")
+			newCandidate.trace(TraceSuffix, "sì", input.Word, newCandidate.Word)
+			d.deconjugateHelper(newCandidate, newPrefixCheck, 1, unlenite, infix, "", "sì", strict, allowReef)
 		}
 		// special case: short genitives of pronouns like "oey" and "ngey"
 		if input.InsistPOS == "any" || input.InsistPOS == "n." {
@@ -1041,13 +1019,15 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				newCandidate.InsistPOS = "pn."
 				newCandidate.Suffixes, added = isDuplicateFix(newCandidate.Suffixes, "y", strict, allowReef)
 				if added {
-					deconjugateHelper(newCandidate, newPrefixCheck, 10, unlenite, []string{}, "", "y", strict, allowReef)
+					newCandidate.traceFix(TraceSuffix, "y", input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, newPrefixCheck, 10, unlenite, []string{}, "", "y", strict, allowReef)
 
 					// ngey to nga
 					if strings.HasSuffix(newCandidate.Word, "e") {
 						newCandidate.Word = strings.TrimSuffix(newCandidate.Word, "e") + "a"
+						newCandidate.trace(TraceRespell, "", strings.TrimSuffix(input.Word, "y"), newCandidate.Word)
 						newCandidate.InsistPOS = "pn."
-						deconjugateHelper(newCandidate, newPrefixCheck, 10, unlenite, []string{}, "", "y", strict, allowReef)
+						d.deconjugateHelper(newCandidate, newPrefixCheck, 10, unlenite, []string{}, "", "y", strict, allowReef)
 					}
 				}
 			}
@@ -1072,60 +1052,81 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 					if !added {
 						continue
 					}
+					newCandidate.traceFix(TraceSuffix, oldSuffix, input.Word, strict, allowReef)
+					suffixed := newCandidate.Trace
 					// all set to 2 to avoid mengeyä -> mengo -> me + 'eng + o
-					deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
+					d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
 
 					if oldSuffix == "ä" && !strings.HasSuffix(input.Word, "yä") && strings.HasSuffix(input.Word, "iä") { // Don't make peyä -> yä -> ya (air)
 						// soaiä, tìftiä, etx.
 						newString += "a"
 						newCandidate.Word = newString
-						deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
+						newCandidate.trace(TraceRespell, "", strings.TrimSuffix(newString, "a"), newString)
+						d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
 					} else if allowReef && oldSuffix == "e" && !strings.HasSuffix(input.Word, "ye") && strings.HasSuffix(input.Word, "ie") {
 						// reef of above
 						newString += "a"
 						newCandidate.Word = newString
-						deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "ä", strict, allowReef)
+						newCandidate.trace(TraceRespell, "", strings.TrimSuffix(newString, "a"), newString)
+						d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "ä", strict, allowReef)
 					} else if (oldSuffix == "yä" || (allowReef && oldSuffix == "ye")) && strings.HasSuffix(newString, "e") {
 						// A one-off
 						if newString == "tse" {
+							newCandidate.Trace = suffixed
 							newCandidate.Word = "tsaw"
-							deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
+							newCandidate.trace(TraceIrregular, "", newString, newCandidate.Word)
+							d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
 						}
 						// ngeyä -> nga
+						newCandidate.Trace = suffixed
 						newCandidate.Word = strings.TrimSuffix(newString, "e") + "a"
-						deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+						d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
 						// oengeyä
+						newCandidate.Trace = suffixed
 						newCandidate.Word = strings.TrimSuffix(newString, "e")
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
 						if newCandidate.Word == "oeng" { //no mengeyä -> meng -> me + 'eng
-							deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
+							d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
 						}
 						// sneyä -> sno
+						newCandidate.Trace = suffixed
 						newCandidate.Word = strings.TrimSuffix(newString, "e") + "o"
-						deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+						d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", oldSuffix, strict, allowReef)
 					} else if !strict && oldSuffix == "ye" && strings.HasSuffix(newString, "e") {
 						// reef of above
 						if newString == "tse" {
+							newCandidate.Trace = suffixed
 							newCandidate.Word = "tsaw"
-							deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
+							newCandidate.trace(TraceIrregular, "", newString, newCandidate.Word)
+							d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
 						}
 						// ngeye -> nga
+						newCandidate.Trace = suffixed
 						newCandidate.Word = strings.TrimSuffix(newString, "e") + "a"
-						deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+						d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
 						// oengeye
+						newCandidate.Trace = suffixed
 						newCandidate.Word = strings.TrimSuffix(newString, "e")
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
 						if newCandidate.Word == "oeng" { //no mengeyä -> meng -> me + 'eng
-							deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
+							d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
 						}
 						// sneye -> sno
+						newCandidate.Trace = suffixed
 						newCandidate.Word = strings.TrimSuffix(newString, "e") + "o"
-						deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+						d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
 					} else if vowels, ok := vowelSuffixes["yä"]; ok {
 						for _, vowel := range vowels {
 							// Make sure zekwä-äo is recognized
 							if strings.HasSuffix(newString, vowel+"-") {
 								newString = strings.TrimSuffix(newString, "-")
 								newCandidate.Word = newString
-								deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
+								newCandidate.trace(TraceRespell, "", newString+"-", newString)
+								d.deconjugateHelper(newCandidate, newPrefixCheck, 2, unlenite, []string{}, "", "yä", strict, allowReef)
 							}
 						}
 					}
@@ -1143,7 +1144,8 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				newCandidate.InsistPOS = "n."
 				newCandidate.Suffixes, added = isDuplicateFix(newCandidate.Suffixes, "pe", strict, allowReef)
 				if added {
-					deconjugateHelper(newCandidate, newPrefixCheck, 4, unlenite, []string{}, "", "pe", strict, allowReef)
+					newCandidate.traceFix(TraceSuffix, "pe", input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, newPrefixCheck, 4, unlenite, []string{}, "", "pe", strict, allowReef)
 				}
 			}
 		}
@@ -1159,9 +1161,10 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 			newCandidate.InsistPOS = "adj."
 			newCandidate.Suffixes, added = isDuplicateFix(newCandidate.Suffixes, "a", strict, allowReef)
 			if added {
-				deconjugateHelper(newCandidate, newPrefixCheck, 4, unlenite, []string{"", "", ""}, "", "a", strict, allowReef)
+				newCandidate.traceFix(TraceSuffix, "a", input.Word, strict, allowReef)
+				d.deconjugateHelper(newCandidate, newPrefixCheck, 4, unlenite, []string{"", "", ""}, "", "a", strict, allowReef)
 				newCandidate.InsistPOS = "v."
-				deconjugateHelper(newCandidate, newPrefixCheck, 4, unlenite, []string{"", "", ""}, "", "a", strict, allowReef)
+				d.deconjugateHelper(newCandidate, newPrefixCheck, 4, unlenite, []string{"", "", ""}, "", "a", strict, allowReef)
 			}
 		}
 
@@ -1176,7 +1179,8 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				newCandidate.InsistPOS = "n."
 				newCandidate.Suffixes, added = isDuplicateFix(newCandidate.Suffixes, "o", strict, allowReef)
 				if added {
-					deconjugateHelper(newCandidate, newPrefixCheck, 4, unlenite, []string{}, "", "o", strict, allowReef)
+					newCandidate.traceFix(TraceSuffix, "o", input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, newPrefixCheck, 4, unlenite, []string{}, "", "o", strict, allowReef)
 
 					// Make sure fya'o-o is recognized
 					if vowels, ok := vowelSuffixes["o"]; ok {
@@ -1185,7 +1189,8 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 							if strings.HasSuffix(newString, vowel+"-") {
 								newString = strings.TrimSuffix(newString, "-")
 								newCandidate.Word = newString
-								deconjugateHelper(newCandidate, newPrefixCheck, 5, unlenite, []string{}, "", "o", strict, allowReef)
+								newCandidate.trace(TraceRespell, "", newString+"-", newString)
+								d.deconjugateHelper(newCandidate, newPrefixCheck, 5, unlenite, []string{}, "", "o", strict, allowReef)
 							}
 						}
 					}
@@ -1200,7 +1205,7 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 				if strings.HasSuffix(input.Word, oldSuffix) {
 					newString = strings.TrimSuffix(input.Word, oldSuffix)
 
-					//candidates = append(candidates, newString)
+					//d.candidates = append(d.candidates, newString)
 					newCandidate := candidateDupe(input)
 					newCandidate.Word = newString
 					newCandidate.InsistPOS = "n."
@@ -1208,7 +1213,8 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 					if !added {
 						continue
 					}
-					deconjugateHelper(newCandidate, newPrefixCheck, 6, unlenite, []string{}, "", oldSuffix, strict, allowReef)
+					newCandidate.traceFix(TraceSuffix, oldSuffix, input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, newPrefixCheck, 6, unlenite, []string{}, "", oldSuffix, strict, allowReef)
 				}
 			}
 		}
@@ -1229,12 +1235,14 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 					if !added {
 						continue
 					}
-					deconjugateHelper(newCandidate, 10, 10, unlenite, []string{}, "", oldSuffix, strict, allowReef) // Don't allow any other prefixes
+					newCandidate.traceFix(TraceSuffix, oldSuffix, input.Word, strict, allowReef)
+					d.deconjugateHelper(newCandidate, 10, 10, unlenite, []string{}, "", oldSuffix, strict, allowReef) // Don't allow any other prefixes
 					// They may turn the InsistPOS back into a noun
 
 					if oldSuffix == "yu" && strings.HasSuffix(newString, "si") {
 						newCandidate.Word = strings.TrimSuffix(newString, "si") + " si"
-						deconjugateHelper(newCandidate, 10, 10, unlenite, []string{}, "", oldSuffix, strict, allowReef) // don't allow any other prefixes or suffixes
+						newCandidate.trace(TraceRespell, "", newString, newCandidate.Word)
+						d.deconjugateHelper(newCandidate, 10, 10, unlenite, []string{}, "", oldSuffix, strict, allowReef) // don't allow any other prefixes or suffixes
 					}
 				}
 			}
@@ -1248,8 +1256,9 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 			!strings.HasSuffix(input.Word, "atsi") {
 			newCandidate := candidateDupe(input)
 			newCandidate.Word = strings.TrimSuffix(input.Word, "si") + " si"
+			newCandidate.trace(TraceRespell, "", input.Word, newCandidate.Word)
 			newCandidate.InsistPOS = "v."
-			deconjugateHelper(newCandidate, newPrefixCheck, suffixCheck, unlenite, infix, "", "", strict, allowReef)
+			d.deconjugateHelper(newCandidate, newPrefixCheck, suffixCheck, unlenite, infix, "", "", strict, allowReef)
 		} else { // If there is a "si", we don't need to check for infixes
 			// Check for infixes
 			runes := []rune(input.Word)
@@ -1268,20 +1277,23 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 								continue
 							}
 							newCandidate.InsistPOS = "v."
-							deconjugateHelper(newCandidate, newPrefixCheck, suffixCheck, unlenite, newInfixes, "", "", strict, allowReef)
+							newCandidate.traceFix(TraceInfix, newInfix, input.Word, strict, allowReef)
+							d.deconjugateHelper(newCandidate, newPrefixCheck, suffixCheck, unlenite, newInfixes, "", "", strict, allowReef)
 
 							if newInfix == "ol" {
 								newCandidate := candidateDupe(input)
 								newCandidate.Word = string(runes[:i]) + "ll" + strings.TrimPrefix(shortString, newInfix)
 								newCandidate.Infixes, _ = isDuplicateFix(newCandidate.Infixes, newInfix, strict, allowReef)
 								newCandidate.InsistPOS = "v."
-								deconjugateHelper(newCandidate, newPrefixCheck, suffixCheck, unlenite, newInfixes, "", "", strict, allowReef)
+								newCandidate.traceFix(TraceInfix, newInfix, input.Word, strict, allowReef)
+								d.deconjugateHelper(newCandidate, newPrefixCheck, suffixCheck, unlenite, newInfixes, "", "", strict, allowReef)
 							} else if newInfix == "er" {
 								newCandidate := candidateDupe(input)
 								newCandidate.Word = string(runes[:i]) + "rr" + strings.TrimPrefix(shortString, newInfix)
 								newCandidate.Infixes, _ = isDuplicateFix(newCandidate.Infixes, newInfix, strict, allowReef)
 								newCandidate.InsistPOS = "v."
-								deconjugateHelper(newCandidate, newPrefixCheck, suffixCheck, unlenite, newInfixes, "", "", strict, allowReef)
+								newCandidate.traceFix(TraceInfix, newInfix, input.Word, strict, allowReef)
+								d.deconjugateHelper(newCandidate, newPrefixCheck, suffixCheck, unlenite, newInfixes, "", "", strict, allowReef)
 							}
 						}
 					}
@@ -1295,26 +1307,27 @@ func deconjugateHelper(input ConjugationCandidate, prefixCheck int, suffixCheck
 		for _, oldPrefix := range unlenitionLetters {
 			// If it has a letter that could have changed for lenition,
 			if strings.HasPrefix(input.Word, oldPrefix) {
-				// put all possibilities in the candidates
+				// put all possibilities in the d.candidates
 				for _, newPrefix := range unlenition[oldPrefix] {
 					newCandidate := candidateDupe(input)
 					newString = newPrefix + strings.TrimPrefix(input.Word, oldPrefix)
 					newCandidate.Word = newString
 					if oldPrefix != newPrefix {
 						newCandidate.Lenition = []string{newPrefix + "→" + oldPrefix}
+						newCandidate.trace(TraceLenition, newCandidate.Lenition[0], input.Word, newCandidate.Word)
 					}
-					deconjugateHelper(newCandidate, prefixCheck, suffixCheck, -1, []string{}, "", "", strict, allowReef)
+					d.deconjugateHelper(newCandidate, prefixCheck, suffixCheck, -1, []string{}, "", "", strict, allowReef)
 				}
 				break // We don't want the "ts" to become "txs"
 			}
 		}
 	}
 
-	return candidates
+	return d.candidates
 }
 
 // Helper for TestDeconjugations
-func allIConfigs(input string, discrimRune rune, replaceRune rune, strict bool, allowReef bool) []string {
+func (d *Dictionary) allIConfigs(input string, discrimRune rune, replaceRune rune, strict bool, allowReef bool) []string {
 	discrim := string(discrimRune)
 	replace := string(replaceRune)
 	cCount := strings.Count(input, discrim)
@@ -1355,7 +1368,7 @@ func allIConfigs(input string, discrimRune rune, replaceRune rune, strict bool,
 			buffer.WriteString(splitString[i+1])
 		}
 
-		newAConfig := dialectCrunch([]string{buffer.String()}, false, strict, allowReef)[0]
+		newAConfig := d.dialectCrunch([]string{buffer.String()}, false, strict, allowReef)[0]
 
 		buffer.Reset()
 
@@ -1369,20 +1382,43 @@ func allIConfigs(input string, discrimRune rune, replaceRune rune, strict bool,
 	return results
 }
 
-func Deconjugate(input string, strict bool, allowReef bool) []ConjugationCandidate {
-	candidates = []ConjugationCandidate{} //empty array of strings
-	candidateMap = map[string]ConjugationCandidate{}
+func (d *Dictionary) Deconjugate(input string, strict bool, allowReef bool) []ConjugationCandidate {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.deconjugate(input, strict, allowReef, nil)
+}
+
+// Deconjugate for those who already hold the lock.
+// The candidates are traced if trace isn't nil, it holds the steps taken before.
+func (d *Dictionary) deconjugate(input string, strict bool, allowReef bool, trace []TraceStep) []ConjugationCandidate {
+	state := deconjugation{
+		Dictionary:   d,
+		candidates:   []ConjugationCandidate{}, //empty array of strings
+		candidateMap: map[string]ConjugationCandidate{},
+	}
 	newCandidate := ConjugationCandidate{}
 	newCandidate.Word = input
 	newCandidate.InsistPOS = "any"
-	deconjugateHelper(newCandidate, 0, 0, 0, []string{"", "", ""}, "", "", strict, allowReef)
+	newCandidate.Trace = trace
+	state.deconjugateHelper(newCandidate, 0, 0, 0, []string{"", "", ""}, "", "", strict, allowReef)
+
+	return state.candidates[1:]
+}
 
-	candidates = candidates[1:]
-	return candidates
+func (d *Dictionary) TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict bool, allowReef bool, umlaut bool) (results []Word) {
+	results, _ = d.testDeconjugations(dict, searchNaviWord, strict, allowReef, umlaut, false)
+	return
 }
 
-func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict bool, allowReef bool, umlaut bool) (results []Word) {
-	conjugations := Deconjugate(searchNaviWord, strict, allowReef)
+// TestDeconjugations, with the steps to every result by traceKey if trace is set
+func (d *Dictionary) testDeconjugations(dict *map[string][]Word, searchNaviWord string, strict bool, allowReef bool, umlaut bool,
+	trace bool) (results []Word, traces map[string][]TraceStep) {
+	var rootTrace []TraceStep
+	if trace {
+		rootTrace = []TraceStep{}
+		traces = map[string][]TraceStep{}
+	}
+	conjugations := d.deconjugate(searchNaviWord, strict, allowReef, rootTrace)
 
 	searchNaviWord = strings.ReplaceAll(searchNaviWord, "ù", "u")
 
@@ -1391,17 +1427,20 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 
 	//For using a to search ä
 	if !strict {
-		allAConfigs = append(allAConfigs, allIConfigs(searchNaviWord, 'a', 'ä', strict, allowReef)...)
+		allAConfigs = append(allAConfigs, d.allIConfigs(searchNaviWord, 'a', 'ä', strict, allowReef)...)
 
 		for _, config := range allAConfigs {
 			allIAConfigs = append(allIAConfigs, config)
-			allIAConfigs = append(allIAConfigs, allIConfigs(config, 'i', 'ì', strict, allowReef)...)
+			allIAConfigs = append(allIAConfigs, d.allIConfigs(config, 'i', 'ì', strict, allowReef)...)
 		}
 
 		for _, a := range allIAConfigs {
-			newCandidate := ConjugationCandidate{Word: a, InsistPOS: "any"}
+			newCandidate := ConjugationCandidate{Word: a, InsistPOS: "any", Trace: rootTrace}
+			if a != searchNaviWord {
+				newCandidate.trace(TraceUnstrict, "", searchNaviWord, a)
+			}
 			conjugations = append(conjugations, newCandidate)
-			conjugations = append(conjugations, Deconjugate(a, strict, allowReef)...)
+			conjugations = append(conjugations, d.deconjugate(a, strict, allowReef, newCandidate.Trace)...)
 		}
 
 		// For using i to search ì
@@ -1415,6 +1454,7 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 				nucleusCount += strings.Count(a.Word, b)
 			}
 			if nucleusCount == 1 && strings.Contains(a.Word, "e") {
+				a.trace(TraceUnreef, "", a.Word, strings.ReplaceAll(a.Word, "e", "ä"))
 				a.Word = strings.ReplaceAll(a.Word, "e", "ä")
 				conjugations = append(conjugations, a)
 			}
@@ -1475,7 +1515,7 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 
 		standardizedWordArray := strings.Split(a, " ")
 		if !strict {
-			standardizedWordArray = dialectCrunch(standardizedWordArray, false, strict, allowReef)
+			standardizedWordArray = d.dialectCrunch(standardizedWordArray, false, strict, allowReef)
 		}
 
 		a = ""
@@ -1487,7 +1527,12 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 		}
 
 		if allowReef {
-			a = dialectCrunch([]string{a}, false, true, true)[0]
+			a = d.dialectCrunch([]string{a}, false, true, true)[0]
+		}
+
+		steps := candidate.Trace
+		if trace && a != candidate.Word {
+			steps = append(slices.Clip(steps), TraceStep{TraceDialect, "", candidate.Word, a})
 		}
 
 		for _, c := range (*dict)[a] {
@@ -1549,8 +1594,8 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 						siVerb := false
 						if len(candidate.Infixes) == 0 {
 							if strict {
-								if _, ok := multiword_words[candidate.Word]; ok {
-									for _, b := range multiword_words[candidate.Word] {
+								if _, ok := d.multiwords[candidate.Word]; ok {
+									for _, b := range d.multiwords[candidate.Word] {
 										if b[0] == "si" {
 											siVerb = true
 											a := c
@@ -1572,8 +1617,8 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 									results = AppendAndAlphabetize(results, a)
 								}
 							} else {
-								if _, ok := multiword_words_loose[candidate.Word]; ok {
-									for _, b := range multiword_words_loose[candidate.Word] {
+								if _, ok := d.multiwordsLoose[candidate.Word]; ok {
+									for _, b := range d.multiwordsLoose[candidate.Word] {
 										if b[0] == "si" {
 											siVerb = true
 											a := c
@@ -1607,7 +1652,7 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 
 						// Does the noun actually contain the verb?
 						noTìftang := strings.TrimPrefix(rebuiltVerb, "'")
-						if strings.Contains(searchNaviWord, noTìftang) || strings.Contains(searchNaviWord, dialectCrunch([]string{rebuiltVerb}, false, strict, allowReef)[0]) {
+						if strings.Contains(searchNaviWord, noTìftang) || strings.Contains(searchNaviWord, d.dialectCrunch([]string{rebuiltVerb}, false, strict, allowReef)[0]) {
 							a := c
 							a.Affixes.Lenition = candidate.Lenition
 							a.Affixes.Prefix = candidate.Prefixes
@@ -1802,7 +1847,7 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 						rebuiltVerbForest := rebuiltVerb
 						rebuiltVerbArray := strings.Split(rebuiltVerb, " ")
 						if !strict || allowReef {
-							rebuiltVerbArray = dialectCrunch(rebuiltVerbArray, false, strict, allowReef)
+							rebuiltVerbArray = d.dialectCrunch(rebuiltVerbArray, false, strict, allowReef)
 						}
 
 						rebuiltVerb = ""
@@ -1817,7 +1862,7 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 						searchNaviWordSquish = strings.ReplaceAll(searchNaviWordSquish, "-", " ")
 
 						if !strict {
-							searchNaviWordSquish = dialectCrunch([]string{searchNaviWordSquish}, false)[0]
+							searchNaviWordSquish = d.dialectCrunch([]string{searchNaviWordSquish}, false)[0]
 						}*/
 
 						if len(candidate.Infixes) == 0 || implContainsAny([]string{rebuiltVerb}, allAConfigs) {
@@ -1870,6 +1915,15 @@ func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict b
 				}
 			}
 		}
+
+		// The first candidate to find a word is the one it shows
+		if trace {
+			for _, word := range results {
+				if _, ok := traces[traceKey(word)]; !ok {
+					traces[traceKey(word)] = steps
+				}
+			}
+		}
 	}
 	return
 }
@@ -1,52 +1,22 @@
 package fwew_lib
 
 import (
-	"bufio"
-	"database/sql"
+	"context"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
 const dictFileName = "dictionary-v2.txt"
 
-var dictionary []Word
-var dictHashLoose map[string][]Word
-var dictHashStrict map[string][]Word
-var dictHashStrictReef map[string][]Word
-var dictionaryCached bool
-var dictHashCached bool
-var dictHash2 MetaDict
-var dictHash2Parenthesis MetaDict
-var dictHash2Cached bool
-var homonyms string
-var oddballs string
-var multiIPA string
-
-type MetaDict struct {
-	EN map[string][]string
-	DE map[string][]string
-	ES map[string][]string
-	ET map[string][]string
-	FR map[string][]string
-	HU map[string][]string
-	IT map[string][]string
-	KO map[string][]string
-	NL map[string][]string
-	PL map[string][]string
-	PT map[string][]string
-	RU map[string][]string
-	SV map[string][]string
-	TR map[string][]string
-	UK map[string][]string
-}
+// Search terms to Na'vi words, by language code
+type MetaDict map[string]map[string][]string
 
 var letterMap = map[rune]int{
 	' ': -1, '\'': 0, 'a': 1, '2': 2, '3': 3,
@@ -60,11 +30,8 @@ var letterMap = map[rune]int{
 	'y': 32, 'z': 33, '-': 34,
 }
 
-var nkx = []string{}
-var nkxSub = map[string]string{}
-
 // A mutex to ensure concurrent requests to the
-// dictionary and phoneme counts will not cause
+// name generator and phoneme counts will not cause
 // the program to crash
 var universalLock sync.Mutex
 var phonoLock sync.Mutex
@@ -216,88 +183,76 @@ func NullDef(definition string) bool {
 	return strings.ToUpper(definition) == "NULL" || len(strings.Trim(definition, " ")) < 1
 }
 
-// If a definition is not available in a certain language, default to English
+// EnglishIfNull fills every missing definition with the English one.
+//
+// Deprecated: the dictionary uses FallbackIfNull, which follows the fallback chains of the languages.
 func EnglishIfNull(word Word) Word {
-	// English
-	if NullDef(word.EN) {
-		word.EN = "(no definition)"
-	}
-
-	// German (Deutsch)
-	if NullDef(word.DE) {
-		word.DE = word.EN
-	}
-
-	// Spanish (Español)
-	if NullDef(word.ES) {
-		word.ES = word.EN
-	}
-
-	// Estonian (Eesti)
-	if NullDef(word.ET) {
-		word.ET = word.EN
-	}
-
-	// French (Français)
-	if NullDef(word.FR) {
-		word.FR = word.EN
-	}
-
-	// Hungarian (Magyar)
-	if NullDef(word.HU) {
-		word.HU = word.EN
-	}
+	// a new map, the word's one is shared with the dictionary
+	definitions := make(map[string]string, len(word.Definitions))
+	maps.Copy(definitions, word.Definitions)
 
-	// Italian (Italiano)
-	if NullDef(word.IT) {
-		word.IT = word.EN
-	}
-
-	// Korean (한국어)
-	if NullDef(word.KO) {
-		word.KO = word.EN
+	// English
+	if NullDef(definitions["en"]) {
+		definitions["en"] = "(no definition)"
 	}
 
-	// Dutch (Nederlands)
-	if NullDef(word.NL) {
-		word.NL = word.EN
+	// every other language
+	for _, code := range LanguageCodes() {
+		if NullDef(definitions[code]) {
+			definitions[code] = definitions["en"]
+		}
 	}
-
-	// Polish (Polski)
-	if NullDef(word.PL) {
-		word.PL = word.EN
+	for code, definition := range definitions {
+		if NullDef(definition) {
+			definitions[code] = definitions["en"]
+		}
 	}
 
-	// Portuguese (Português)
-	if NullDef(word.PT) {
-		word.PT = word.EN
-	}
+	word.Definitions = definitions
+	return word
+}
 
-	// Russian (Русский)
-	if NullDef(word.RU) {
-		word.RU = word.EN
+// FallbackIfNull fills every missing definition with the one of the first language of its FallbackChain that has one.
+// Word.Fallbacks tells which language each filled in definition is from,
+// definitions none of the chain has become "(no definition)" and are not in it.
+func FallbackIfNull(word Word) Word {
+	// a new map, the word's one is shared with the dictionary
+	definitions := make(map[string]string, len(word.Definitions))
+	maps.Copy(definitions, word.Definitions)
+	fallbacks := map[string]string{}
+
+	fill := func(code string) {
+		if !NullDef(definitions[code]) {
+			return
+		}
+		for _, fallback := range FallbackChain(code)[1:] {
+			if definition := word.Definitions[fallback]; !NullDef(definition) {
+				definitions[code] = definition
+				fallbacks[code] = fallback
+				return
+			}
+		}
+		// no language of the chain has one, so it is not from any of them
+		definitions[code] = "(no definition)"
 	}
 
-	// Swedish (Svenska)
-	if NullDef(word.SV) {
-		word.SV = word.EN
+	for _, code := range LanguageCodes() {
+		fill(code)
 	}
-
-	// Turkish (Türkçe)
-	if NullDef(word.TR) {
-		word.TR = word.EN
+	for code := range word.Definitions {
+		fill(code)
 	}
 
-	// Ukrainian (Українська)
-	if NullDef(word.UK) {
-		word.UK = word.EN
+	word.Definitions = definitions
+	word.Fallbacks = nil
+	if len(fallbacks) > 0 {
+		word.Fallbacks = fallbacks
 	}
-
 	return word
 }
 
 // Helper function to get phonetic transcriptions of secondary pronunciations
-// Only multiple IPA words will call this function
+// Only multiple IPA words will call this function, malformed IPA gives ""
 func RomanizeSecondIPA(IPA string) string {
 	// now Romanize the IPA
 	IPA = strings.ReplaceAll(IPA, "ʊ", "u")
@@ -315,323 +270,375 @@ func RomanizeSecondIPA(IPA string) string {
 			continue
 		}
 
-		syllables := strings.Split(word[j], ".")
-
-		/* Onset */
-		for k := 0; k < len(syllables); k++ {
-			syllable := strings.ReplaceAll(syllables[k], "·", "")
-			syllable = strings.ReplaceAll(syllable, "ˈ", "")
-			syllable = strings.ReplaceAll(syllable, "ˌ", "")
-
-			// tsy
-			if strings.HasPrefix(syllable, "tʃ") {
-				breakdown += "ch"
-				syllable = strings.TrimPrefix(syllable, "tʃ")
-			} else if len(syllable) >= 4 && syllable[0:4] == "t͡s" {
-				// ts
-				breakdown += "ts"
-				//tsp
-				if hasAt("ptk", syllable, 3) {
-					if nth_rune(syllable, 4) == "'" {
-						// ts + ejective onset
-						breakdown += romanization2[syllable[4:6]]
-						syllable = syllable[6:]
-					} else {
-						// ts + unvoiced plosive
-						breakdown += romanization2[string(syllable[4])]
-						syllable = syllable[5:]
-					}
-				} else if hasAt("lɾmnŋwj", syllable, 3) {
-					// ts + other consonent
-					breakdown += romanization2[nth_rune(syllable, 3)]
-					syllable = syllable[4+len(nth_rune(syllable, 3)):]
+		romanized, ok := romanizeIPAWord(word[j])
+		if !ok {
+			return ""
+		}
+		breakdown += romanized + " "
+	}
+	return strings.TrimSuffix(breakdown, " ")
+}
+
+// Romanize one word of an IPA transcription, ok is false if a syllable is malformed
+func romanizeIPAWord(ipaWord string) (breakdown string, ok bool) {
+	syllables := strings.Split(ipaWord, ".")
+
+	/* Onset */
+	for k := 0; k < len(syllables); k++ {
+		syllable := strings.ReplaceAll(syllables[k], "·", "")
+		syllable = strings.ReplaceAll(syllable, "ˈ", "")
+		syllable = strings.ReplaceAll(syllable, "ˌ", "")
+
+		// tsy
+		if strings.HasPrefix(syllable, "tʃ") {
+			breakdown += "ch"
+			syllable = strings.TrimPrefix(syllable, "tʃ")
+		} else if len(syllable) >= 4 && syllable[0:4] == "t͡s" {
+			// ts
+			breakdown += "ts"
+			//tsp
+			if hasAt("ptk", syllable, 3) {
+				if nth_rune(syllable, 4) == "'" {
+					// ts + ejective onset
+					breakdown += romanization2[syllable[4:6]]
+					syllable = syllable[6:]
 				} else {
-					// ts without a cluster
-					syllable = syllable[4:]
+					// ts + unvoiced plosive
+					breakdown += romanization2[string(syllable[4])]
+					syllable = syllable[5:]
 				}
-			} else if hasAt("fs", syllable, 0) {
-				//
-				breakdown += nth_rune(syllable, 0)
-				if hasAt("ptk", syllable, 1) {
-					if nth_rune(syllable, 2) == "'" {
-						// f/s + ejective onset
-						breakdown += romanization2[syllable[1:3]]
-						syllable = syllable[3:]
-					} else {
-						// f/s + unvoiced plosive
-						breakdown += romanization2[string(syllable[1])]
-						syllable = syllable[2:]
-					}
-				} else if hasAt("lɾmnŋwj", syllable, 1) {
-					// f/s + other consonent
-					breakdown += romanization2[nth_rune(syllable, 1)]
-					syllable = syllable[1+len(nth_rune(syllable, 1)):]
+			} else if hasAt("lɾmnŋwj", syllable, 3) {
+				// ts + other consonent
+				breakdown += romanization2[nth_rune(syllable, 3)]
+				syllable = syllable[4+len(nth_rune(syllable, 3)):]
+			} else {
+				// ts without a cluster
+				syllable = syllable[4:]
+			}
+		} else if hasAt("fs", syllable, 0) {
+			//
+			breakdown += nth_rune(syllable, 0)
+			if hasAt("ptk", syllable, 1) {
+				if nth_rune(syllable, 2) == "'" {
+					// f/s + ejective onset
+					breakdown += romanization2[syllable[1:3]]
+					syllable = syllable[3:]
 				} else {
-					// f/s without a cluster
-					syllable = syllable[1:]
-				}
-			} else if hasAt("ptk", syllable, 0) {
-				if nth_rune(syllable, 1) == "'" {
-					// ejective
-					breakdown += romanization2[syllable[0:2]]
+					// f/s + unvoiced plosive
+					breakdown += romanization2[string(syllable[1])]
 					syllable = syllable[2:]
-				} else {
-					// unvoiced plosive
-					breakdown += romanization2[string(syllable[0])]
-					syllable = syllable[1:]
 				}
-			} else if hasAt("ʔlɾhmnŋvwjzbdg", syllable, 0) {
-				// other normal onset
-				breakdown += romanization2[nth_rune(syllable, 0)]
-				syllable = syllable[len(nth_rune(syllable, 0)):]
-			} else if hasAt("ʃʒ", syllable, 0) {
-				// one sound representd as a cluster
-				if nth_rune(syllable, 0) == "ʃ" {
-					breakdown += "sh"
-				}
-				syllable = syllable[len(nth_rune(syllable, 0)):]
+			} else if hasAt("lɾmnŋwj", syllable, 1) {
+				// f/s + other consonent
+				breakdown += romanization2[nth_rune(syllable, 1)]
+				syllable = syllable[1+len(nth_rune(syllable, 1)):]
+			} else {
+				// f/s without a cluster
+				syllable = syllable[1:]
 			}
-
-			/*
-			 * Nucleus
-			 */
-			if len(syllable) > 1 && hasAt("jw", syllable, 1) {
-				//diphthong
-				breakdown += romanization2[syllable[0:len(nth_rune(syllable, 0))+1]]
-				syllable = string([]rune(syllable)[2:])
-			} else if len(syllable) > 1 && hasAt("lr", syllable, 0) {
-				breakdown += romanization2[syllable[0:3]]
-				continue
+		} else if hasAt("ptk", syllable, 0) {
+			if nth_rune(syllable, 1) == "'" {
+				// ejective
+				breakdown += romanization2[syllable[0:2]]
+				syllable = syllable[2:]
 			} else {
-				//vowel
-				breakdown += romanization2[nth_rune(syllable, 0)]
-				syllable = string([]rune(syllable)[1:])
+				// unvoiced plosive
+				breakdown += romanization2[string(syllable[0])]
+				syllable = syllable[1:]
+			}
+		} else if hasAt("ʔlɾhmnŋvwjzbdg", syllable, 0) {
+			// other normal onset
+			breakdown += romanization2[nth_rune(syllable, 0)]
+			syllable = syllable[len(nth_rune(syllable, 0)):]
+		} else if hasAt("ʃʒ", syllable, 0) {
+			// one sound representd as a cluster
+			if nth_rune(syllable, 0) == "ʃ" {
+				breakdown += "sh"
 			}
+			syllable = syllable[len(nth_rune(syllable, 0)):]
+		}
 
-			/*
-			 * Coda
-			 */
-			if len(syllable) > 0 {
-				if nth_rune(syllable, 0) == "s" {
-					breakdown += "sss" //oìsss only
+		/*
+		 * Nucleus
+		 */
+		if syllable == "" {
+			// no vowel left after the onset
+			return breakdown, false
+		}
+		if len(syllable) > 1 && hasAt("jw", syllable, 1) {
+			//diphthong
+			breakdown += romanization2[syllable[0:len(nth_rune(syllable, 0))+1]]
+			syllable = string([]rune(syllable)[2:])
+		} else if len(syllable) > 1 && hasAt("lr", syllable, 0) {
+			// syllabic l̩ or r̩
+			if len(syllable) < 3 {
+				return breakdown, false
+			}
+			breakdown += romanization2[syllable[0:3]]
+			continue
+		} else {
+			//vowel
+			breakdown += romanization2[nth_rune(syllable, 0)]
+			syllable = string([]rune(syllable)[1:])
+		}
+
+		/*
+		 * Coda
+		 */
+		if len(syllable) > 0 {
+			if nth_rune(syllable, 0) == "s" {
+				breakdown += "sss" //oìsss only
+			} else {
+				if syllable == "k̚" {
+					breakdown += "k"
+				} else if syllable == "p̚" {
+					breakdown += "p"
+				} else if syllable == "t̚" {
+					breakdown += "t"
+				} else if syllable == "ʔ̚" {
+					breakdown += "'"
 				} else {
-					if syllable == "k̚" {
-						breakdown += "k"
-					} else if syllable == "p̚" {
-						breakdown += "p"
-					} else if syllable == "t̚" {
-						breakdown += "t"
-					} else if syllable == "ʔ̚" {
-						breakdown += "'"
+					if syllable[0] == 'k' && len(syllable) > 1 {
+						breakdown += "kx"
 					} else {
-						if syllable[0] == 'k' && len(syllable) > 1 {
-							breakdown += "kx"
-						} else {
-							breakdown += romanization2[syllable]
-						}
+						breakdown += romanization2[syllable]
 					}
 				}
 			}
 		}
-		breakdown += " "
 	}
-	return strings.TrimSuffix(breakdown, " ")
+	return breakdown, true
 }
 
-func UncacheDict() {
-	dictionaryCached = false
-	dictionary = []Word{}
+func (d *Dictionary) UncacheDict() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.uncacheDict()
 }
 
-func CacheDict() error {
-	var err error
+func (d *Dictionary) uncacheDict() {
+	d.wordsCached = false
+	d.words = []Word{}
+	d.completions = nil
+}
 
-	UncacheDict()
-	err = runOnDB(func(word Word) error {
-		dictionary = append(dictionary, word)
+// CacheDict caches the words only, see Load.
+// Like Load, it reads on the side and keeps the old words if that fails.
+func (d *Dictionary) CacheDict() error {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
+	fresh := NewDictionary(d.Source())
+	err := fresh.source.Run(func(word Word) error {
+		fresh.words = append(fresh.words, word)
+		fresh.addLanguages(word)
 		return nil
 	})
-
-	if err == nil {
-		fmt.Println("cache 0 loaded (SQL)")
-	} else {
-		UncacheDict()
-		err = runOnFile(func(word Word) error {
-			dictionary = append(dictionary, word)
-			return nil
-		})
-		//fmt.Println("cache 0 loaded (File)")
-	}
-
 	if err != nil {
-		UncacheDict()
 		return err
 	}
 
-	dictionaryCached = true
+	sortByID(fresh.words)
+	fresh.wordsCached = true
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.takeWords(fresh)
 
 	return nil
 }
 
-func CacheDictHash() error {
-	err := CacheDictHashOrig(true)
-	if err == nil {
-		fmt.Println("cache 1 loaded (SQL)")
-	} else {
-		err = CacheDictHashOrig(false)
-		//fmt.Println("cache 1 loaded (File)")
+// The words have to be in the order of their ID, for e.g. `words first 10` to work
+func sortByID(words []Word) {
+	if len(words) == 0 {
+		return
+	}
+	firstWordID, _ := strconv.Atoi(words[0].ID)
+	if firstWordID > 100 {
+		slices.SortFunc(words, func(a, b Word) int {
+			a1, _ := strconv.Atoi(a.ID)
+			b1, _ := strconv.Atoi(b.ID)
+			return a1 - b1
+		})
 	}
-	return err
 }
 
 // This will cache the whole dictionary (Na'vi to natural language).
 // Please call this, if you want to translate multiple words or running infinitely (e.g. CLI-go-prompt, discord-bot)
-func CacheDictHashOrig(mysql bool) error {
+// Like Load, it builds on the side and keeps the old caches if that fails.
+func (d *Dictionary) CacheDictHash() error {
+	return d.cacheDictHashFrom(d.Source())
+}
+
+// CacheDictHash reading from source instead of the dictionary's own
+func (d *Dictionary) cacheDictHashFrom(source DictionarySource) error {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
 	// dont run if already is cached
-	if len(dictHashLoose) != 0 {
+	d.lock.RLock()
+	cached := len(d.hashLoose) != 0
+	d.lock.RUnlock()
+	if cached {
 		return nil
-	} else {
-		dictHashLoose = make(map[string][]Word)
-		dictHashStrict = make(map[string][]Word)
-		dictHashStrictReef = make(map[string][]Word)
 	}
 
+	fresh := NewDictionary(source)
+	fresh.resetHash()
+
 	tempHoms := []string{}
+	err := fresh.source.Run(func(word Word) error {
+		fresh.addLanguages(word)
+		fresh.hashWord(word, &tempHoms)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error caching dictionary: %s", err)
+		return err
+	}
 
-	//Clear to avoid duplicates
-	multiIPA = ""
+	fresh.finishHash(tempHoms)
 
-	var f = func(word Word) error {
-		standardizedWord := word.Navi
-		badChars := `~@#$%^&*()[]{}<>_/.,;:!?|+\"„“”«»`
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.takeHash(fresh)
 
-		// remove all the sketchy chars from arguments
-		for _, c := range badChars {
-			standardizedWord = strings.ReplaceAll(standardizedWord, string(c), "")
-		}
+	return nil
+}
 
-		// normalize tìftang character
-		standardizedWord = strings.ReplaceAll(standardizedWord, "’", "'")
-		standardizedWord = strings.ReplaceAll(standardizedWord, "‘", "'")
+// Empty the Na'vi to natural language maps before hashing
+func (d *Dictionary) resetHash() {
+	d.hashLoose = make(map[string][]Word)
+	d.hashStrict = make(map[string][]Word)
+	d.hashStrictReef = make(map[string][]Word)
+	d.hashIPA = make(map[string][]Word)
+	d.hashSoundsLike = make(map[string][]Word)
 
-		// find everything lowercase
-		standardizedWord = strings.ToLower(standardizedWord)
+	//Clear to avoid duplicates
+	d.multiIPA = ""
+	d.nkx = []string{}
+	d.nkxSub = map[string]string{}
+}
 
-		// Make sure we know of every word with nkx
-		if strings.Contains(standardizedWord, "nkx") {
-			fakeNG := strings.ReplaceAll(standardizedWord, "nkx", "ng")
-			nkx = shortestFirst(nkx, fakeNG)
-			nkxSub[fakeNG] = standardizedWord
-		}
+// Put one word into the Na'vi to natural language maps.
+// Possible homonyms are collected in tempHoms.
+func (d *Dictionary) hashWord(word Word, tempHoms *[]string) {
+	standardizedWord := word.Navi
+	badChars := `~@#$%^&*()[]{}<>_/.,;:!?|+\"„“”«»`
 
-		standardizedWordArray := dialectCrunch(strings.Split(standardizedWord, " "), true, false, true)
-		standardizedWordLoose := ""
-		for i, a := range standardizedWordArray {
-			if i != 0 {
-				standardizedWordLoose += " "
-			}
-			standardizedWordLoose += a
-		}
+	// remove all the sketchy chars from arguments
+	for _, c := range badChars {
+		standardizedWord = strings.ReplaceAll(standardizedWord, string(c), "")
+	}
 
-		strictReefArray := dialectCrunch(strings.Split(standardizedWord, " "), true, true, true)
-		strictReef := ""
-		for i, a := range strictReefArray {
-			if i != 0 {
-				strictReef += " "
-			}
-			strictReef += a
-		}
+	// normalize tìftang character
+	standardizedWord = strings.ReplaceAll(standardizedWord, "’", "'")
+	standardizedWord = strings.ReplaceAll(standardizedWord, "‘", "'")
 
-		// If the word appears more than once, record it
-		if _, ok := dictHashStrict[standardizedWord]; ok {
-			found := false
-			for _, a := range tempHoms {
-				if a == standardizedWord {
-					found = true
-					break
-				}
-			}
-			if !found {
-				tempHoms = append(tempHoms, standardizedWord)
-			}
+	// find everything lowercase
+	standardizedWord = strings.ToLower(standardizedWord)
+
+	// Make sure we know of every word with nkx
+	if strings.Contains(standardizedWord, "nkx") {
+		fakeNG := strings.ReplaceAll(standardizedWord, "nkx", "ng")
+		d.nkx = shortestFirst(d.nkx, fakeNG)
+		d.nkxSub[fakeNG] = standardizedWord
+	}
+
+	standardizedWordArray := d.dialectCrunch(strings.Split(standardizedWord, " "), true, false, true)
+	standardizedWordLoose := ""
+	for i, a := range standardizedWordArray {
+		if i != 0 {
+			standardizedWordLoose += " "
 		}
+		standardizedWordLoose += a
+	}
 
-		if strings.Contains(standardizedWord, "é") {
-			noAcute := strings.ReplaceAll(standardizedWord, "é", "e")
-			found := false
-			for _, a := range tempHoms {
-				if a == noAcute {
-					found = true
-					break
-				}
-			}
-			if !found {
-				tempHoms = append(tempHoms, noAcute)
-				tempHoms = append(tempHoms, standardizedWord)
-			}
+	strictReefArray := d.dialectCrunch(strings.Split(standardizedWord, " "), true, true, true)
+	strictReef := ""
+	for i, a := range strictReefArray {
+		if i != 0 {
+			strictReef += " "
 		}
+		strictReef += a
+	}
 
-		word = EnglishIfNull(word)
-		dictHashLoose[standardizedWordLoose] = append(dictHashLoose[standardizedWordLoose], word)
-		dictHashStrictReef[strictReef] = append(dictHashStrictReef[strictReef], word)
-		dictHashStrict[standardizedWord] = append(dictHashStrict[standardizedWord], word)
-
-		//find words with multiple IPAs
-		if strings.Contains(word.IPA, " or ") {
-			multiIPA += word.Navi + " "
-			secondTerm := RomanizeSecondIPA(word.IPA)
-			if secondTerm != standardizedWord {
-				dictHashLoose[dialectCrunch([]string{secondTerm}, true, false, true)[0]] = append(dictHashLoose[dialectCrunch([]string{secondTerm}, true, false, true)[0]], word)
-				dictHashStrictReef[dialectCrunch([]string{secondTerm}, true, true, true)[0]] = append(dictHashStrictReef[dialectCrunch([]string{secondTerm}, true, true, true)[0]], word)
-				dictHashStrict[secondTerm] = append(dictHashStrict[secondTerm], word)
+	// If the word appears more than once, record it
+	if _, ok := d.hashStrict[standardizedWord]; ok {
+		found := false
+		for _, a := range *tempHoms {
+			if a == standardizedWord {
+				found = true
+				break
 			}
 		}
+		if !found {
+			*tempHoms = append(*tempHoms, standardizedWord)
+		}
+	}
 
-		// See whether or not it violates normal phonotactic rules like Jakesully or Oìsss
-		valid := true
-		for _, a := range strings.Split(IsValidNavi(word.Navi, "en", false), "\n") {
-			// Check every word.  If one of them isn't good, write down the word
-			if len(a) > 0 && (!strings.Contains(a, "Valid:") || strings.Contains(a, "reef")) {
-				valid = false
+	if strings.Contains(standardizedWord, "é") {
+		noAcute := strings.ReplaceAll(standardizedWord, "é", "e")
+		found := false
+		for _, a := range *tempHoms {
+			if a == noAcute {
+				found = true
 				break
 			}
 		}
-		if !valid {
-			oddballs += word.Navi + " "
+		if !found {
+			*tempHoms = append(*tempHoms, noAcute)
+			*tempHoms = append(*tempHoms, standardizedWord)
 		}
-
-		return nil
 	}
 
-	var err error
-	if mysql {
-		err = runOnDB(f)
-		if err != nil {
-			UncacheHashDict()
-			return err
+	word = FallbackIfNull(word)
+	d.hashLoose[standardizedWordLoose] = append(d.hashLoose[standardizedWordLoose], word)
+	d.hashStrictReef[strictReef] = append(d.hashStrictReef[strictReef], word)
+	d.hashStrict[standardizedWord] = append(d.hashStrict[standardizedWord], word)
+	d.hashPronunciation(word)
+
+	//find words with multiple IPAs
+	if strings.Contains(word.IPA, " or ") {
+		d.multiIPA += word.Navi + " "
+		secondTerm := RomanizeSecondIPA(word.IPA)
+		if secondTerm != "" && secondTerm != standardizedWord {
+			d.hashLoose[d.dialectCrunch([]string{secondTerm}, true, false, true)[0]] = append(d.hashLoose[d.dialectCrunch([]string{secondTerm}, true, false, true)[0]], word)
+			d.hashStrictReef[d.dialectCrunch([]string{secondTerm}, true, true, true)[0]] = append(d.hashStrictReef[d.dialectCrunch([]string{secondTerm}, true, true, true)[0]], word)
+			d.hashStrict[secondTerm] = append(d.hashStrict[secondTerm], word)
 		}
-	} else {
-		err = runOnFile(f)
-		if err != nil {
-			log.Printf("Error caching dictionary: %s", err)
-			UncacheHashDict()
-			return err
+	}
+
+	// See whether or not it violates normal phonotactic rules like Jakesully or Oìsss
+	if isOddball(word.Navi) {
+		d.oddballs += word.Navi + " "
+	}
+}
+
+// isOddball is true if any word of navi breaks the forest phonotactic rules
+func isOddball(navi string) bool {
+	for _, a := range strings.Split(IsValidNavi(navi, "en", false), "\n") {
+		// Check every word.  If one of them isn't good, it's an oddball
+		if len(a) > 0 && (!strings.Contains(a, "Valid:") || strings.Contains(a, "reef")) {
+			return true
 		}
 	}
+	return false
+}
 
+// Turn the collected homonyms into a string and mark the hash as cached
+func (d *Dictionary) finishHash(tempHoms []string) {
 	// Reverse the order to make accidental and new homonyms easier to see
 	// Also make it a string for easier searching
 	i := len(tempHoms)
 	for i > 0 {
 		i--
-		homonyms += tempHoms[i] + " "
+		d.homonyms += tempHoms[i] + " "
 	}
 
-	homonyms = strings.TrimSuffix(homonyms, " ")
+	d.homonyms = strings.TrimSuffix(d.homonyms, " ")
 
-	dictHashCached = true
-
-	return nil
+	d.hashCached = true
 }
 
 // Turn a definition into its searchable terms
@@ -676,8 +683,10 @@ func SearchTerms(input string, excludeParen bool) []string {
 
 // Helper function for CacheDictHash2
 func AssignWord(wordmap map[string][]string, natlangWords string, naviWord string, excludeParen bool) (result map[string][]string) {
-	newWords := SearchTerms(natlangWords, excludeParen)
+	return assignTerms(wordmap, SearchTerms(natlangWords, excludeParen), naviWord)
+}
 
+func assignTerms(wordmap map[string][]string, newWords []string, naviWord string) map[string][]string {
 	for i := 0; i < len(newWords); i++ {
 		duplicate := false
 		for j := 0; j < len(wordmap[newWords[i]]); j++ {
@@ -694,230 +703,209 @@ func AssignWord(wordmap map[string][]string, natlangWords string, naviWord strin
 }
 
 // Natural languages to Na'vi
-func CacheDictHash2() error {
-	err := CacheDictHash2Orig(true)
-	if err == nil {
-		fmt.Println("cache 2 loaded (SQL)")
-	} else {
-		err = CacheDictHash2Orig(false)
-		//fmt.Println("cache 2 loaded (File)")
-	}
-	return err
+func (d *Dictionary) CacheDictHash2() error {
+	return d.cacheDictHash2From(d.Source())
 }
 
-func CacheDictHash2Orig(mysql bool) error {
+// CacheDictHash2 reading from source instead of the dictionary's own
+func (d *Dictionary) cacheDictHash2From(source DictionarySource) error {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
 	// dont run if already is cached
-	if len(dictHash2.EN) != 0 {
+	d.lock.RLock()
+	cached := len(d.hash2["en"]) != 0
+	d.lock.RUnlock()
+	if cached {
 		return nil
-	} else {
-		dictHash2.EN = make(map[string][]string)
-		dictHash2.DE = make(map[string][]string)
-		dictHash2.ES = make(map[string][]string)
-		dictHash2.ET = make(map[string][]string)
-		dictHash2.FR = make(map[string][]string)
-		dictHash2.HU = make(map[string][]string)
-		dictHash2.IT = make(map[string][]string)
-		dictHash2.KO = make(map[string][]string)
-		dictHash2.NL = make(map[string][]string)
-		dictHash2.PL = make(map[string][]string)
-		dictHash2.PT = make(map[string][]string)
-		dictHash2.RU = make(map[string][]string)
-		dictHash2.SV = make(map[string][]string)
-		dictHash2.TR = make(map[string][]string)
-		dictHash2.UK = make(map[string][]string)
-
-		dictHash2Parenthesis.EN = make(map[string][]string)
-		dictHash2Parenthesis.DE = make(map[string][]string)
-		dictHash2Parenthesis.ES = make(map[string][]string)
-		dictHash2Parenthesis.ET = make(map[string][]string)
-		dictHash2Parenthesis.FR = make(map[string][]string)
-		dictHash2Parenthesis.HU = make(map[string][]string)
-		dictHash2Parenthesis.IT = make(map[string][]string)
-		dictHash2Parenthesis.KO = make(map[string][]string)
-		dictHash2Parenthesis.NL = make(map[string][]string)
-		dictHash2Parenthesis.PL = make(map[string][]string)
-		dictHash2Parenthesis.PT = make(map[string][]string)
-		dictHash2Parenthesis.RU = make(map[string][]string)
-		dictHash2Parenthesis.SV = make(map[string][]string)
-		dictHash2Parenthesis.TR = make(map[string][]string)
-		dictHash2Parenthesis.UK = make(map[string][]string)
 	}
 
-	// Set up the whole thing
+	fresh := NewDictionary(source)
+	fresh.resetHash2()
 
-	var setUpTheWholeThing = func(word Word) error {
-		standardizedWord := strings.ToLower(word.Navi)
-		standardizedWord = strings.ReplaceAll(standardizedWord, "+", "")
+	err := fresh.source.Run(func(word Word) error {
+		fresh.addLanguages(word)
+		fresh.hash2Word(word)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error caching dictionary: %s", err)
+		return err
+	}
 
-		// English
-		if !NullDef(word.EN) {
-			dictHash2.EN = AssignWord(dictHash2.EN, word.EN, standardizedWord, true)
-			dictHash2Parenthesis.EN = AssignWord(dictHash2Parenthesis.EN, word.EN, standardizedWord, false)
-		}
+	fresh.hash2Cached = true
 
-		// German (Deutsch)
-		if !NullDef(word.DE) {
-			dictHash2.DE = AssignWord(dictHash2.DE, word.DE, standardizedWord, true)
-			dictHash2Parenthesis.DE = AssignWord(dictHash2Parenthesis.DE, word.DE, standardizedWord, false)
-		}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.takeHash2(fresh)
 
-		// Spanish (Español)
-		if !NullDef(word.ES) {
-			dictHash2.ES = AssignWord(dictHash2.ES, word.ES, standardizedWord, true)
-			dictHash2Parenthesis.ES = AssignWord(dictHash2Parenthesis.ES, word.ES, standardizedWord, false)
-		}
+	return nil
+}
 
-		// Estonian (Eesti)
-		if !NullDef(word.ET) {
-			dictHash2.ET = AssignWord(dictHash2.ET, word.ET, standardizedWord, true)
-			dictHash2Parenthesis.ET = AssignWord(dictHash2Parenthesis.ET, word.ET, standardizedWord, false)
-		}
+// Empty the natural language to Na'vi maps before hashing
+func (d *Dictionary) resetHash2() {
+	d.hash2 = MetaDict{}
+	d.hash2Parenthesis = MetaDict{}
+	d.hash2Positions = positionDict{}
+	d.hash2Normalized = MetaDict{}
+	d.completions = nil
+}
 
-		// French (Français)
-		if !NullDef(word.FR) {
-			dictHash2.FR = AssignWord(dictHash2.FR, word.FR, standardizedWord, true)
-			dictHash2Parenthesis.FR = AssignWord(dictHash2Parenthesis.FR, word.FR, standardizedWord, false)
-		}
+// Put the definitions of one word into the natural language to Na'vi maps
+func (d *Dictionary) hash2Word(word Word) {
+	standardizedWord := strings.ToLower(word.Navi)
+	standardizedWord = strings.ReplaceAll(standardizedWord, "+", "")
 
-		// Hungarian (Magyar)
-		if !NullDef(word.HU) {
-			dictHash2.HU = AssignWord(dictHash2.HU, word.HU, standardizedWord, true)
-			dictHash2Parenthesis.HU = AssignWord(dictHash2Parenthesis.HU, word.HU, standardizedWord, false)
+	for code, definition := range word.Definitions {
+		if NullDef(definition) {
+			continue
 		}
-
-		// Italian (Italiano)
-		if !NullDef(word.IT) {
-			dictHash2.IT = AssignWord(dictHash2.IT, word.IT, standardizedWord, true)
-			dictHash2Parenthesis.IT = AssignWord(dictHash2Parenthesis.IT, word.IT, standardizedWord, false)
+		if d.hash2[code] == nil {
+			d.hash2[code] = make(map[string][]string)
+			d.hash2Parenthesis[code] = make(map[string][]string)
+			d.hash2Positions[code] = make(map[string][]termPositions)
+			d.hash2Normalized[code] = make(map[string][]string)
 		}
-
-		// Korean (한국어)
-		if !NullDef(word.KO) {
-			dictHash2.KO = AssignWord(dictHash2.KO, word.KO, standardizedWord, true)
-			dictHash2Parenthesis.KO = AssignWord(dictHash2Parenthesis.KO, word.KO, standardizedWord, false)
+		d.hash2[code] = AssignWord(d.hash2[code], definition, standardizedWord, true)
+		d.hash2Parenthesis[code] = AssignWord(d.hash2Parenthesis[code], definition, standardizedWord, false)
+		for term, positions := range definitionPositions(definition) {
+			d.hash2Positions[code][term] = append(d.hash2Positions[code][term], termPositions{ID: word.ID, Positions: positions})
 		}
+		d.hash2Normalized[code] = assignTerms(d.hash2Normalized[code], normalizeTerms(code, SearchTerms(definition, false)), standardizedWord)
+	}
+}
 
-		// Dutch (Nederlands)
-		if !NullDef(word.NL) {
-			dictHash2.NL = AssignWord(dictHash2.NL, word.NL, standardizedWord, true)
-			dictHash2Parenthesis.NL = AssignWord(dictHash2Parenthesis.NL, word.NL, standardizedWord, false)
-		}
+// Find every entry with spaces in it, so the translators know which words
+// may start a multiword word
+func (d *Dictionary) cacheMultiwords(words []Word) {
+	// new maps, someone might still be looking at the old ones
+	d.multiwords = map[string][][]string{}
+	d.multiwordsLoose = map[string][][]string{}
+	d.multiwordsReef = map[string][][]string{}
+
+	for _, word := range words {
+		all_words := strings.Split(strings.ToLower(word.Navi), " ")
+		if len(all_words) > 1 {
+			new_words := d.dialectCrunch(all_words, true, true, false)
+			new_words_reef := d.dialectCrunch(all_words, true, true, true)
+			if _, ok := d.multiwordsLoose[new_words[0]]; ok {
+				// Ensure no duplicates
+				appended := false
+
+				// Append in a way that makes the longer words first
+				temp := [][]string{}
+				for _, j := range d.multiwordsLoose[new_words[0]] {
+					if !appended && len([]rune(new_words[1])) > len([]rune(j[0])) {
+						temp = append(temp, new_words[1:])
+						appended = true
+					}
+					temp = append(temp, j)
+				}
+				if len(temp) <= len(d.multiwordsLoose[new_words[0]]) {
+					temp = append(temp, new_words[1:])
+				}
 
-		// Polish (Polski)
-		if !NullDef(word.PL) {
-			dictHash2.PL = AssignWord(dictHash2.PL, word.PL, standardizedWord, true)
-			dictHash2Parenthesis.PL = AssignWord(dictHash2Parenthesis.PL, word.PL, standardizedWord, false)
-		}
+				d.multiwordsLoose[new_words[0]] = temp
+			} else {
+				d.multiwordsLoose[new_words[0]] = [][]string{new_words[1:]}
+			}
 
-		// Portuguese (Português)
-		if !NullDef(word.PT) {
-			dictHash2.PT = AssignWord(dictHash2.PT, word.PT, standardizedWord, true)
-			dictHash2Parenthesis.PT = AssignWord(dictHash2Parenthesis.PT, word.PT, standardizedWord, false)
-		}
+			if _, ok := d.multiwordsReef[new_words_reef[0]]; ok {
+				// Ensure no duplicates
+				appended := false
 
-		// Russian (Русский)
-		if !NullDef(word.RU) {
-			dictHash2.RU = AssignWord(dictHash2.RU, word.RU, standardizedWord, true)
-			dictHash2Parenthesis.RU = AssignWord(dictHash2Parenthesis.RU, word.RU, standardizedWord, false)
-		}
+				// Append in a way that makes the longer words first
+				temp := [][]string{}
+				for _, j := range d.multiwordsReef[new_words_reef[0]] {
+					if !appended && len([]rune(new_words_reef[1])) > len([]rune(j[0])) {
+						temp = append(temp, new_words_reef[1:])
+						appended = true
+					}
+					temp = append(temp, j)
+				}
+				if len(temp) <= len(d.multiwordsReef[new_words_reef[0]]) {
+					temp = append(temp, new_words_reef[1:])
+				}
 
-		// Swedish (Svenska)
-		if !NullDef(word.SV) {
-			dictHash2.SV = AssignWord(dictHash2.SV, word.SV, standardizedWord, true)
-			dictHash2Parenthesis.SV = AssignWord(dictHash2Parenthesis.SV, word.SV, standardizedWord, false)
-		}
+				d.multiwordsReef[new_words_reef[0]] = temp
+			} else {
+				d.multiwordsReef[new_words_reef[0]] = [][]string{new_words_reef[1:]}
+			}
 
-		// Turkish (Türkçe)
-		if !NullDef(word.TR) {
-			dictHash2.TR = AssignWord(dictHash2.TR, word.TR, standardizedWord, true)
-			dictHash2Parenthesis.TR = AssignWord(dictHash2Parenthesis.TR, word.TR, standardizedWord, false)
-		}
+			if _, ok := d.multiwords[all_words[0]]; ok {
+				// Ensure no duplicates
+				appended := false
 
-		// Ukrainian (Українська)
-		if !NullDef(word.UK) {
-			dictHash2.UK = AssignWord(dictHash2.UK, word.UK, standardizedWord, true)
-			dictHash2Parenthesis.UK = AssignWord(dictHash2Parenthesis.UK, word.UK, standardizedWord, false)
-		}
-		return nil
-	}
+				// Append in a way that makes the longer words first
+				temp := [][]string{}
+				for _, j := range d.multiwords[all_words[0]] {
+					if !appended && len([]rune(all_words[1])) > len([]rune(j[0])) {
+						temp = append(temp, all_words[1:])
+						appended = true
+					}
+					temp = append(temp, j)
+				}
+				if len(temp) <= len(d.multiwords[all_words[0]]) {
+					temp = append(temp, all_words[1:])
+				}
 
-	var err error
-	if mysql {
-		err = runOnDB(setUpTheWholeThing)
-		if err != nil {
-			UncacheHashDict2()
-			return err
-		}
-	} else {
-		err = runOnFile(setUpTheWholeThing)
-		if err != nil {
-			log.Printf("Error caching dictionary: %s", err)
-			UncacheHashDict2()
-			return err
+				d.multiwords[all_words[0]] = temp
+			} else {
+				d.multiwords[all_words[0]] = [][]string{all_words[1:]}
+			}
 		}
 	}
+}
 
-	dictHash2Cached = true
+func (d *Dictionary) UncacheHashDict() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.uncacheHashDict()
+}
 
-	return nil
+func (d *Dictionary) uncacheHashDict() {
+	d.hashCached = false
+	d.hashLoose = nil
+	d.hashStrict = nil
+	d.hashIPA = nil
+	d.hashSoundsLike = nil
+	d.homonyms = ""
+	d.oddballs = ""
 }
 
-func UncacheHashDict() {
-	dictHashCached = false
-	dictHashLoose = nil
-	dictHashStrict = nil
-	homonyms = ""
-	oddballs = ""
+func (d *Dictionary) UncacheHashDict2() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.uncacheHashDict2()
 }
 
-func UncacheHashDict2() {
-	dictHash2Cached = false
-	dictHash2.EN = nil
-	dictHash2.DE = nil
-	dictHash2.ES = nil
-	dictHash2.ET = nil
-	dictHash2.FR = nil
-	dictHash2.HU = nil
-	dictHash2.IT = nil
-	dictHash2.KO = nil
-	dictHash2.NL = nil
-	dictHash2.PL = nil
-	dictHash2.PT = nil
-	dictHash2.RU = nil
-	dictHash2.SV = nil
-	dictHash2.TR = nil
-	dictHash2.UK = nil
-
-	dictHash2Parenthesis.EN = nil
-	dictHash2Parenthesis.DE = nil
-	dictHash2Parenthesis.ES = nil
-	dictHash2Parenthesis.ET = nil
-	dictHash2Parenthesis.FR = nil
-	dictHash2Parenthesis.HU = nil
-	dictHash2Parenthesis.IT = nil
-	dictHash2Parenthesis.KO = nil
-	dictHash2Parenthesis.NL = nil
-	dictHash2Parenthesis.PL = nil
-	dictHash2Parenthesis.PT = nil
-	dictHash2Parenthesis.RU = nil
-	dictHash2Parenthesis.SV = nil
-	dictHash2Parenthesis.TR = nil
-	dictHash2Parenthesis.UK = nil
+func (d *Dictionary) uncacheHashDict2() {
+	d.hash2Cached = false
+	d.hash2 = nil
+	d.hash2Parenthesis = nil
+	d.hash2Positions = nil
+	d.hash2Normalized = nil
+	d.completions = nil
 }
 
 // This will run the function `f` inside the cache or the file directly.
 // Use this to get words out of the dictionary
 // function `f` is called on every single line in the dictionary!
-func RunOnDict(f func(word Word) error) (err error) {
-	if dictionaryCached {
-		for _, word := range dictionary {
+func (d *Dictionary) RunOnDict(f func(word Word) error) (err error) {
+	// the cached words are never changed, only replaced, so they can be used without the lock
+	d.lock.RLock()
+	words, wordsCached, source := d.words, d.wordsCached, d.source
+	d.lock.RUnlock()
+
+	if wordsCached {
+		for _, word := range words {
 			err = f(word)
 			if err != nil {
 				return
 			}
 		}
 	} else {
-		err = runOnFile(func(word Word) error {
+		err = source.Run(func(word Word) error {
 			err = f(word)
 			if err != nil {
 				return err
@@ -929,134 +917,18 @@ func RunOnDict(f func(word Word) error) (err error) {
 	return
 }
 
-func runOnDB(f func(word Word) error) error {
-	user := os.Getenv("FW_USER")
-	pass := os.Getenv("FW_PASS")
-	host := os.Getenv("FW_HOST")
-	name := os.Getenv("FW_DB")
-	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s)/%s", user, pass, host, name)
-	db, err := sql.Open("mysql", dataSourceName)
-	if err != nil {
-		return err
-	}
-	defer db.Close()
-
-	rows, err1 := db.Query("SELECT " +
-		"m.id, m.navi, m.ipa, m.infixes, m.partOfSpeech, s.source, b.stressed, b.syllables, b.infixDots, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'de') AS de, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'en') AS en, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'es') AS es, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'et') AS et, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'fr') AS fr, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'hu') AS hu, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'it') AS it, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'ko') AS ko, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'nl') AS nl, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'pl') AS pl, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'pt') AS pt, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'ru') AS ru, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'sv') AS sv, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'tr') AS tr, " +
-		"(SELECT localized FROM fwedit_localizedWords AS l WHERE l.id = m.id AND languageCode = 'uk') AS uk " +
-		"FROM fwedit_metaWords AS m " +
-		"INNER JOIN fwedit_sources AS s ON (m.id = s.id) " +
-		"INNER JOIN fwedit_breakdown AS b ON (s.id = b.id)")
-
-	if err1 != nil {
-		return err1
-	}
-
-	var w Word
-	var de, en, es, et, fr, hu, it, ko, nl, pl, pt, ru, sv, tr, uk []byte
-
-	for rows.Next() {
-		err = rows.Scan(&w.ID, &w.Navi, &w.IPA, &w.InfixLocations, &w.PartOfSpeech, &w.Source, &w.Stressed,
-			&w.Syllables, &w.InfixDots, &de, &en, &es, &et, &fr, &hu, &it, &ko, &nl, &pl, &pt, &ru, &sv, &tr, &uk)
-
-		if err != nil {
-			return err
-		}
-
-		w.DE = string(de)
-		w.EN = string(en)
-		w.ES = string(es)
-		w.ET = string(et)
-		w.FR = string(fr)
-		w.HU = string(hu)
-		w.IT = string(it)
-		w.KO = string(ko)
-		w.NL = string(nl)
-		w.PL = string(pl)
-		w.PT = string(pt)
-		w.RU = string(ru)
-		w.SV = string(sv)
-		w.TR = string(tr)
-		w.UK = string(uk)
-
-		err = f(w)
-
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+func (d *Dictionary) GetFullDict() (allWords []Word, err error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.fullDict()
 }
 
-func runOnFile(f func(word Word) error) error {
-	dictionaryFile := FindDictionaryFile()
-	if dictionaryFile == "" {
-		return DictionaryNotFound
-	}
-
-	file, err := os.Open(dictionaryFile)
-	if err != nil {
-		log.Printf("Error opening the dictionary file %s", err)
-		return err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-
-	var first = true
-	var pos dictPos
-	for scanner.Scan() {
-		// get a single line out of the dict
-		line := scanner.Text()
-
-		// Split line at \t so we get all information
-		fields := strings.Split(line, "\t")
-
-		// When first then this is the header
-		if first {
-			pos = readDictPos(fields)
-			first = false
-		} else {
-			// Put the stuff from fields into the Word struct
-			err = f(newWord(fields, pos))
-			if err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-func GetFullDict() (allWords []Word, err error) {
-	// No need for the lock because only List() calls it
-	if dictionaryCached {
-		firstWordID, _ := strconv.Atoi(dictionary[0].ID)
-		if firstWordID > 100 {
-			slices.SortFunc(dictionary, func(a, b Word) int {
-				a1, _ := strconv.Atoi(a.ID)
-				b1, _ := strconv.Atoi(b.ID)
-				return a1 - b1
-			})
-		}
-		allWords = dictionary
+// GetFullDict for those who already hold the lock
+func (d *Dictionary) fullDict() (allWords []Word, err error) {
+	if d.wordsCached {
+		allWords = d.words
 	} else {
-		err = runOnFile(func(word Word) error {
+		err = d.source.Run(func(word Word) error {
 			allWords = append(allWords, word)
 			return nil
 		})
@@ -1066,22 +938,31 @@ func GetFullDict() (allWords []Word, err error) {
 }
 
 // Just a number
-func GetDictSizeSimple() (count int) {
-	universalLock.Lock()
-	defer universalLock.Unlock()
-	return len(dictionary)
+func (d *Dictionary) GetDictSizeSimple() (count int) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return len(d.words)
+}
+
+// The sentence GetDictSize returns, by language code.  Languages without one get the English one.
+var dictSizeSentences = map[string]string{
+	"en": "There are %d entries in the dictionary.",
+	"de": "Es sind %d Einträge im Wörterbuch.",
+	"fr": "Il y a %d définitions dans le dictionnaire.",
+	"it": "Ci sono %d voci nel dizionario.",
+	"ko": "Fwew에는 %d개의 단어가 등록되어 있습니다.",
 }
 
 // Return a complete sentence
-func GetDictSize(lang string) (count string, err error) {
-	universalLock.Lock()
-	defer universalLock.Unlock()
+func (d *Dictionary) GetDictSize(lang string) (count string, err error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	// Count words
 	amount := 0
-	if dictionaryCached {
-		amount = len(dictionary)
+	if d.wordsCached {
+		amount = len(d.words)
 	} else {
-		err = runOnFile(func(word Word) error {
+		err = d.source.Run(func(word Word) error {
 			amount++
 			return nil
 		})
@@ -1089,89 +970,77 @@ func GetDictSize(lang string) (count string, err error) {
 
 	// Put the word count into a complete sentence
 	count = strconv.Itoa(amount)
-
-	if lang == "en" { // English
-		count = "There are " + count + " entries in the dictionary."
-	} else if lang == "de" { // German (Deutsch)
-		count = "Es sind " + count + " Einträge im Wörterbuch."
-	} else if lang == "es" { // Spanish (Español)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "et" { // Estonian (Eesti)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "fr" { // French (Français)
-		count = "Il y a " + count + " définitions dans le dictionnaire."
-	} else if lang == "hu" { // Hungarian (Magyar)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "it" {
-		count = "Ci sono " + count + " voci nel dizionario."
-	} else if lang == "ko" { // Korean (한국어)
-		count = "Fwew에는 " + count + "개의 단어가 등록되어 있습니다."
-	} else if lang == "nl" { // Dutch (Nederlands)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "pl" { // Polish (Polski)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "pt" { // Portuguese (Português)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "ru" { // Russian (Русский)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "sv" { // Swedish (Svenska)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "tr" { // Turkish (Türkçe)
-		count = "There are " + count + " entries in the dictionary." // TODO
-	} else if lang == "uk" { // Ukrainian (Українська)
-		count = "There are " + count + " entries in the dictionary." // TODO
+	if sentence, ok := dictSizeSentences[lang]; ok {
+		count = fmt.Sprintf(sentence, amount)
+	} else if slices.Contains(d.languageCodes(), lang) {
+		count = fmt.Sprintf(dictSizeSentences["en"], amount)
 	}
 
 	return
 }
 
-// Update the dictionary.txt.
-// universalLock will hopefully prevent anything from accessing
-// the dict while updating
-func UpdateDict() error {
-	universalLock.Lock()
-	defer universalLock.Unlock()
-	err := DownloadDict("")
-	if err != nil {
-		log.Println(Text("downloadError"))
-		return err
-	}
-
-	err = CacheDict()
-	if err != nil {
-		log.Printf("Error caching dict after updatig ... Cache disabled")
-		return err
-	}
+// Update the dictionary.txt and load it again.
+// Lookups keep using the old dictionary until the new one is ready,
+// and if anything fails, the old dictionary stays in use.
+func (d *Dictionary) UpdateDict() error {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+	_, err := d.UpdateDictWith(ctx, &Downloader{})
+	return err
+}
 
-	if dictHashCached {
-		UncacheHashDict()
+// UpdateDictWith is UpdateDict downloading with the given downloader.
+// It returns what changed in the dictionary, e.g. to announce new words.
+// If the dictionary file didn't change, it is not loaded again and the diff is empty.
+// If the dictionary wasn't loaded before, every entry is in diff.Added.
+func (d *Dictionary) UpdateDictWith(ctx context.Context, downloader *Downloader) (diff DictionaryDiff, err error) {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
+	// swap only replaces the slice, so the old one can be compared after the reload
+	d.lock.RLock()
+	old := d.words
+	loaded := d.wordsCached
+	d.lock.RUnlock()
+
+	// only a dictionary file can be downloaded, other sources are just read again
+	if file, ok := d.Source().(*FileSource); ok {
+		updated, err := downloader.Download(ctx, file.Path)
+		if err != nil {
+			log.Println(Text("downloadError"))
+			return diff, err
+		}
+		if !updated && loaded {
+			return diff, nil
+		}
 	}
 
-	err = CacheDictHash()
+	err = d.reload()
 	if err != nil {
-		log.Printf("Error caching dict after updating ... Cache disabled")
-		return err
+		log.Printf("Error caching dict after updating ... keeping the old one")
+		return
 	}
 
-	if dictHash2Cached {
-		UncacheHashDict2()
+	// the version shows the build of the default dictionary only
+	if source, ok := d.Source().(HashedSource); ok && d == defaultDictionary {
+		if hash, err := source.Hash(); err == nil {
+			setDictBuild(hash)
+		}
 	}
 
-	err = CacheDictHash2()
-	if err != nil {
-		log.Printf("Error caching dict after updating ... Cache disabled")
-		return err
-	}
+	d.lock.RLock()
+	diff = DiffWords(old, d.words)
+	d.lock.RUnlock()
 
-	return nil
+	return
 }
 
 // AssureDict will assure, that the dictionary exists.
-// If no dictionary is found, it will be downloaded next of the executable.
+// If no dictionary is found and there is no fallback dictionary, it will be downloaded next of the executable.
 func AssureDict() error {
 	// check if dict already exists
 	file := FindDictionaryFile()
-	if file != "" {
+	if file != "" || fallbackDictionary != nil {
 		return nil
 	}
 
@@ -1183,5 +1052,8 @@ func AssureDict() error {
 		return err
 	}
 
+	// the default dictionary is loaded from it now
+	setDictBuild(dictBuild())
+
 	return nil
 }
@@ -17,28 +17,30 @@ func Test_cacheDict(t *testing.T) {
 		Source:         "Activist Survival Guide (2009-11-24) | https://naviteri.org/2012/11/renu-ayinanfyaya-the-senses-paradigm/ (2012-11-27)",
 		Stressed:       "1",
 		Syllables:      "'am-pi",
-		DE:             "berühren",
-		EN:             "touch",
-		ES:             "tocar",
-		ET:             "katsuma, puutuma",
-		FR:             "toucher",
-		HU:             "(meg)érint",
-		IT:             "toccare",
-		KO:             "만지다",
-		NL:             "aanraken",
-		PL:             "dotykać",
-		PT:             "tocar",
-		RU:             "трогать, прикасаться",
-		SV:             "beröra",
-		TR:             "dokunmak",
-		UK:             "торкатися",
+		Definitions: map[string]string{
+			"de": "berühren",
+			"en": "touch",
+			"es": "tocar",
+			"et": "katsuma, puutuma",
+			"fr": "toucher",
+			"hu": "(meg)érint",
+			"it": "toccare",
+			"ko": "만지다",
+			"nl": "aanraken",
+			"pl": "dotykać",
+			"pt": "tocar",
+			"ru": "трогать, прикасаться",
+			"sv": "beröra",
+			"tr": "dokunmak",
+			"uk": "торкатися",
+		},
 	}
 
 	err := CacheDictHash()
 	if err != nil {
 		t.Fatalf("Error caching Dictionary!!")
 	}
-	entry := dictHashLoose["'ampi"]
+	entry := defaultDictionary.hashLoose["'ampi"]
 	if !word.Equals(entry[0]) {
 		t.Errorf("Read wrong word from cache:\n"+
 			"Id: \"%s\" == \"%s\"\n"+
@@ -49,21 +51,7 @@ func Test_cacheDict(t *testing.T) {
 			"Source: \"%s\" == \"%s\"\n"+
 			"Stressed: \"%s\" == \"%s\"\n"+
 			"Syllables: \"%s\" == \"%s\"\n"+
-			"DE: \"%s\" == \"%s\"\n"+
-			"EN: \"%s\" == \"%s\"\n"+
-			"ES: \"%s\" == \"%s\"\n"+
-			"ET: \"%s\" == \"%s\"\n"+
-			"FR: \"%s\" == \"%s\"\n"+
-			"HU: \"%s\" == \"%s\"\n"+
-			"IT: \"%s\" == \"%s\"\n"+
-			"KO: \"%s\" == \"%s\"\n"+
-			"NL: \"%s\" == \"%s\"\n"+
-			"PL: \"%s\" == \"%s\"\n"+
-			"PT: \"%s\" == \"%s\"\n"+
-			"RU: \"%s\" == \"%s\"\n"+
-			"SV: \"%s\" == \"%s\"\n"+
-			"TR: \"%s\" == \"%s\"\n"+
-			"UK: \"%s\" == \"%s\"\n"+
+			"Definitions: %v == %v\n"+
 			"InfixDots: \"%s\" == \"%s\"\n",
 			word.ID, entry[0].ID,
 			word.Navi, entry[0].Navi,
@@ -73,21 +61,7 @@ func Test_cacheDict(t *testing.T) {
 			word.Source, entry[0].Source,
 			word.Stressed, entry[0].Stressed,
 			word.Syllables, entry[0].Syllables,
-			word.DE, entry[0].DE,
-			word.EN, entry[0].EN,
-			word.ES, entry[0].ES,
-			word.ET, entry[0].ET,
-			word.FR, entry[0].FR,
-			word.HU, entry[0].HU,
-			word.IT, entry[0].IT,
-			word.KO, entry[0].KO,
-			word.NL, entry[0].NL,
-			word.PL, entry[0].PL,
-			word.PT, entry[0].PT,
-			word.RU, entry[0].RU,
-			word.SV, entry[0].SV,
-			word.TR, entry[0].TR,
-			word.UK, entry[0].UK,
+			word.Definitions, entry[0].Definitions,
 			word.InfixDots, entry[0].InfixDots)
 	}
 }
@@ -0,0 +1,432 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. catalog.go is the list of all affixes,
+// the deconjugator in affixes_hash.go and Conjugate in conjugate.go take theirs from here.
+package fwew_lib
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// AffixKind is where an affix goes on a word
+type AffixKind string
+
+const (
+	PrefixAffix AffixKind = "prefix"
+	InfixAffix  AffixKind = "infix"
+	SuffixAffix AffixKind = "suffix"
+)
+
+// AffixSlot is the place of an affix among the others of its kind
+type AffixSlot string
+
+const (
+	SlotAttributive AffixSlot = "attributive" // a, nì and -a
+	SlotVerb        AffixSlot = "verb"        // tsuk, ketsuk and -yu, -tswo, -tseng
+	SlotDeterminer  AffixSlot = "determiner"  // fì, tsa, pe, fra and -pe
+	SlotNumber      AffixSlot = "number"      // pxe, ay, me
+	SlotStem        AffixSlot = "stem"        // fne, sna, munsna and -tsyìp, -fkeyk
+	SlotGerund      AffixSlot = "gerund"      // tì with <us>
+	SlotDerivation  AffixSlot = "derivation"  // the unproductive ones that make new words, like le
+	SlotPrefirst    AffixSlot = "prefirst"    // <äp>, <eyk>
+	SlotFirst       AffixSlot = "first"       // tense, aspect, mood and participles
+	SlotSecond      AffixSlot = "second"      // attitude
+	SlotSome        AffixSlot = "some"        // -o
+	SlotCase        AffixSlot = "case"        // the case endings
+	SlotAdposition  AffixSlot = "adposition"  // -mì, -kip, ...
+	SlotAnd         AffixSlot = "and"         // -sì
+)
+
+// Productivity tells if an affix can go on every word of its parts of speech, see ListAffixes
+type Productivity string
+
+const (
+	AnyProductivity Productivity = ""
+	Productive      Productivity = "productive"
+	Unproductive    Productivity = "unproductive"
+)
+
+// Affix is an entry of the affix catalog, see ListAffixes
+type Affix struct {
+	Form string
+	Kind AffixKind
+	Slot AffixSlot
+	// The parts of speech of the words it goes on, like "n." or "v.", empty for every word
+	PartsOfSpeech []string
+	Productivity  Productivity
+	// Whether it lenites the word after it
+	Lenition bool
+	// The other forms of it, like ìl of l
+	Allomorphs []string
+	// Other spellings of it that strict mode accepts too, like iyev of ìyev
+	Variants []string
+	// Its reef and loose spellings and the forms they stand for, see unreefFixes and unstrictFixes
+	Reef  map[string]string
+	Loose map[string]string
+	// The vowels it follows with a hyphen, like the o of fya'o-o
+	Hyphen []string
+	// What it means by language code, adpositions have their definitions in the dictionary
+	Gloss map[string]string
+}
+
+func gloss(en string, de string) map[string]string {
+	return map[string]string{"en": en, "de": de}
+}
+
+var nouns = []string{n}
+var verbs = []string{"v."}
+var attributives = []string{adj, num, "v."}
+
+// Every affix, the order within a slot is the order the deconjugator tries them in
+var affixCatalog = slices.Concat(boundAffixes, adpositionAffixes())
+
+var boundAffixes = []Affix{
+	// prefixes
+	{Form: "a", Kind: PrefixAffix, Slot: SlotAttributive, PartsOfSpeech: attributives, Productivity: Productive,
+		Gloss: gloss("attributive marker, links an adjective or participle to a noun", "Attributmarker, verbindet ein Adjektiv oder Partizip mit einem Nomen")},
+	{Form: "nì", Kind: PrefixAffix, Slot: SlotAttributive, PartsOfSpeech: []string{adj, num}, Productivity: Productive,
+		Gloss: gloss("makes an adverb of an adjective", "macht aus einem Adjektiv ein Adverb")},
+	{Form: "tsuk", Kind: PrefixAffix, Slot: SlotVerb, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("able to be done, -able", "-bar")},
+	{Form: "ketsuk", Kind: PrefixAffix, Slot: SlotVerb, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("unable to be done, un-...-able", "un-...-bar")},
+	{Form: "fì", Kind: PrefixAffix, Slot: SlotDeterminer, PartsOfSpeech: nouns, Productivity: Productive,
+		Loose: map[string]string{"fi": "fì"}, Gloss: gloss("this", "dieser")},
+	{Form: "tsa", Kind: PrefixAffix, Slot: SlotDeterminer, PartsOfSpeech: nouns, Productivity: Productive,
+		Gloss: gloss("that", "jener")},
+	{Form: "pe", Kind: PrefixAffix, Slot: SlotDeterminer, PartsOfSpeech: nouns, Productivity: Productive, Lenition: true,
+		Gloss: gloss("which?", "welcher?")},
+	{Form: "fra", Kind: PrefixAffix, Slot: SlotDeterminer, PartsOfSpeech: nouns, Productivity: Productive,
+		Gloss: gloss("every", "jeder")},
+	{Form: "pxe", Kind: PrefixAffix, Slot: SlotNumber, PartsOfSpeech: nouns, Productivity: Productive, Lenition: true,
+		Gloss: gloss("trial, three of", "Trial, drei")},
+	{Form: "ay", Kind: PrefixAffix, Slot: SlotNumber, PartsOfSpeech: nouns, Productivity: Productive, Lenition: true,
+		Gloss: gloss("plural", "Plural")},
+	{Form: "me", Kind: PrefixAffix, Slot: SlotNumber, PartsOfSpeech: nouns, Productivity: Productive, Lenition: true,
+		Gloss: gloss("dual, two of", "Dual, zwei")},
+	{Form: "fne", Kind: PrefixAffix, Slot: SlotStem, PartsOfSpeech: nouns, Productivity: Productive,
+		Gloss: gloss("type of, kind of", "Art von")},
+	{Form: "sna", Kind: PrefixAffix, Slot: SlotStem, PartsOfSpeech: nouns, Productivity: Productive,
+		Gloss: gloss("set of, group of", "Menge von")},
+	{Form: "munsna", Kind: PrefixAffix, Slot: SlotStem, PartsOfSpeech: nouns, Productivity: Productive,
+		Gloss: gloss("pair of", "Paar von")},
+	{Form: "tì", Kind: PrefixAffix, Slot: SlotGerund, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("gerund with <us>, the act of doing", "Gerundium mit <us>, das Tun")},
+	{Form: "tì", Kind: PrefixAffix, Slot: SlotDerivation, PartsOfSpeech: []string{"v.", adj}, Productivity: Unproductive,
+		Gloss: gloss("makes a noun of a verb or adjective", "macht aus einem Verb oder Adjektiv ein Nomen")},
+	{Form: "le", Kind: PrefixAffix, Slot: SlotDerivation, PartsOfSpeech: nouns, Productivity: Unproductive,
+		Gloss: gloss("makes an adjective of a noun", "macht aus einem Nomen ein Adjektiv")},
+	{Form: "sä", Kind: PrefixAffix, Slot: SlotDerivation, PartsOfSpeech: verbs, Productivity: Unproductive,
+		Gloss: gloss("makes an instrument of a verb, the means of doing", "macht aus einem Verb ein Werkzeug")},
+	{Form: "ke", Kind: PrefixAffix, Slot: SlotDerivation, PartsOfSpeech: []string{adj, "v."}, Productivity: Unproductive,
+		Gloss: gloss("not, un-", "nicht, un-")},
+
+	// infixes
+	{Form: "äp", Kind: InfixAffix, Slot: SlotPrefirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Reef: map[string]string{"ep": "äp"}, Loose: map[string]string{"ap": "äp"}, Gloss: gloss("reflexive", "reflexiv")},
+	{Form: "äpeyk", Kind: InfixAffix, Slot: SlotPrefirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Reef: map[string]string{"epeyk": "äpeyk"}, Gloss: gloss("reflexive and causative", "reflexiv und kausativ")},
+	{Form: "eyk", Kind: InfixAffix, Slot: SlotPrefirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("causative, make someone do", "kausativ, jemanden etwas tun lassen")},
+	{Form: "ay", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("future", "Futur")},
+	{Form: "asy", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("intentional future", "beabsichtigtes Futur")},
+	{Form: "aly", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("perfective future", "perfektives Futur")},
+	{Form: "ary", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("imperfective future", "imperfektives Futur")},
+	{Form: "ìy", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Loose: map[string]string{"iy": "ìy"}, Gloss: gloss("near future", "nahes Futur")},
+	{Form: "ìsy", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Loose: map[string]string{"isy": "ìsy"}, Gloss: gloss("intentional near future", "beabsichtigtes nahes Futur")},
+	{Form: "ìly", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Loose: map[string]string{"ily": "ìly"}, Gloss: gloss("perfective near future", "perfektives nahes Futur")},
+	{Form: "ìry", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Loose: map[string]string{"iry": "ìry"}, Gloss: gloss("imperfective near future", "imperfektives nahes Futur")},
+	{Form: "ol", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("perfective", "perfektiv")},
+	{Form: "er", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("imperfective", "imperfektiv")},
+	{Form: "ìm", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Loose: map[string]string{"im": "ìm"}, Gloss: gloss("recent past", "nahe Vergangenheit")},
+	{Form: "ìlm", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Loose: map[string]string{"ilm": "ìlm"}, Gloss: gloss("perfective recent past", "perfektive nahe Vergangenheit")},
+	{Form: "ìrm", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Loose: map[string]string{"irm": "ìrm"}, Gloss: gloss("imperfective recent past", "imperfektive nahe Vergangenheit")},
+	{Form: "am", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("past", "Vergangenheit")},
+	{Form: "alm", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("perfective past", "perfektive Vergangenheit")},
+	{Form: "arm", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("imperfective past", "imperfektive Vergangenheit")},
+	{Form: "ìyev", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Variants: []string{"iyev"}, Gloss: gloss("near future subjunctive", "Konjunktiv des nahen Futurs")},
+	{Form: "iv", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("subjunctive", "Konjunktiv")},
+	{Form: "ilv", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("perfective subjunctive", "perfektiver Konjunktiv")},
+	{Form: "irv", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("imperfective subjunctive", "imperfektiver Konjunktiv")},
+	{Form: "imv", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("past subjunctive", "Konjunktiv der Vergangenheit")},
+	{Form: "us", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("active participle, -ing", "Partizip Aktiv")},
+	{Form: "awn", Kind: InfixAffix, Slot: SlotFirst, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("passive participle, -ed", "Partizip Passiv")},
+	{Form: "ei", Kind: InfixAffix, Slot: SlotSecond, PartsOfSpeech: verbs, Productivity: Productive,
+		Allomorphs: []string{"eiy"}, Gloss: gloss("positive attitude", "positive Haltung")},
+	{Form: "äng", Kind: InfixAffix, Slot: SlotSecond, PartsOfSpeech: verbs, Productivity: Productive,
+		Reef: map[string]string{"eng": "äng"}, Loose: map[string]string{"ang": "äng"}, Gloss: gloss("negative attitude", "negative Haltung")},
+	{Form: "uy", Kind: InfixAffix, Slot: SlotSecond, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("formal, ceremonial", "förmlich, feierlich")},
+	{Form: "ats", Kind: InfixAffix, Slot: SlotSecond, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("inferential, it seems", "vermutend, anscheinend")},
+
+	// suffixes
+	{Form: "tswo", Kind: SuffixAffix, Slot: SlotVerb, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("the ability to do", "die Fähigkeit zu tun")},
+	{Form: "yu", Kind: SuffixAffix, Slot: SlotVerb, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("one who does, -er", "jemand, der etwas tut, -er")},
+	{Form: "tseng", Kind: SuffixAffix, Slot: SlotVerb, PartsOfSpeech: verbs, Productivity: Productive,
+		Gloss: gloss("the place of doing", "der Ort des Tuns")},
+	{Form: "tsyìp", Kind: SuffixAffix, Slot: SlotStem, PartsOfSpeech: nouns, Productivity: Productive,
+		Loose: map[string]string{"tsyip": "tsyìp"}, Gloss: gloss("diminutive, little", "Verkleinerung, -chen")},
+	{Form: "fkeyk", Kind: SuffixAffix, Slot: SlotStem, PartsOfSpeech: nouns, Productivity: Productive,
+		Gloss: gloss("state of", "Zustand von")},
+	{Form: "o", Kind: SuffixAffix, Slot: SlotSome, PartsOfSpeech: nouns, Productivity: Productive,
+		Hyphen: []string{"o"}, Gloss: gloss("some, indefinite", "irgendein")},
+	{Form: "pe", Kind: SuffixAffix, Slot: SlotDeterminer, PartsOfSpeech: nouns, Productivity: Productive,
+		Gloss: gloss("which?", "welcher?")},
+	{Form: "a", Kind: SuffixAffix, Slot: SlotAttributive, PartsOfSpeech: attributives, Productivity: Productive,
+		Gloss: gloss("attributive marker, links an adjective or participle to a noun", "Attributmarker, verbindet ein Adjektiv oder Partizip mit einem Nomen")},
+	{Form: "l", Kind: SuffixAffix, Slot: SlotCase, PartsOfSpeech: nouns, Productivity: Productive,
+		Allomorphs: []string{"ìl"}, Loose: map[string]string{"il": "ìl"}, Gloss: gloss("agentive, the one doing something to something", "Agentiv, wer etwas mit etwas tut")},
+	{Form: "t", Kind: SuffixAffix, Slot: SlotCase, PartsOfSpeech: nouns, Productivity: Productive,
+		Allomorphs: []string{"it", "ti"}, Gloss: gloss("patientive, what something is done to", "Patientiv, womit etwas getan wird")},
+	{Form: "r", Kind: SuffixAffix, Slot: SlotCase, PartsOfSpeech: nouns, Productivity: Productive,
+		Allomorphs: []string{"ur", "ru"}, Gloss: gloss("dative, to", "Dativ, zu")},
+	{Form: "yä", Kind: SuffixAffix, Slot: SlotCase, PartsOfSpeech: nouns, Productivity: Productive,
+		Allomorphs: []string{"ä"}, Reef: map[string]string{"ye": "yä", "e": "ä"}, Gloss: gloss("genitive, of", "Genitiv, von")},
+	{Form: "ri", Kind: SuffixAffix, Slot: SlotCase, PartsOfSpeech: nouns, Productivity: Productive,
+		Allomorphs: []string{"ìri"}, Loose: map[string]string{"iri": "ìri"}, Gloss: gloss("topical, as for", "Topikal, was ... angeht")},
+	{Form: "sì", Kind: SuffixAffix, Slot: SlotAnd, Productivity: Productive,
+		Gloss: gloss("and", "und")},
+}
+
+// The adpositions that go on nouns like suffixes, the ones that can be mistaken for case endings first
+var adpositions = []Affix{
+	{Form: "pxel"}, {Form: "mungwrr"},
+	{Form: "kxamlä", Reef: map[string]string{"kxamle": "kxamlä"}},
+	// ile is reef and loose
+	{Form: "ìlä", Reef: map[string]string{"ìle": "ìlä", "ile": "ilä"}, Loose: map[string]string{"ilä": "ìlä"}, Hyphen: []string{"ì"}},
+	{Form: "wä", Reef: map[string]string{"we": "wä"}},
+	{Form: "nuä", Reef: map[string]string{"nue": "nuä"}},
+	{Form: "teri"},
+	{Form: "ftumfa"}, {Form: "nemfa"}, {Form: "rofa"}, {Form: "ka"}, {Form: "fa"}, {Form: "na"}, {Form: "ta"},
+	{Form: "ya"}, {Form: "yoa"}, {Form: "krrka"}, {Form: "ftuopa"},
+	{Form: "lisre"}, {Form: "pxisre"}, {Form: "sre"}, {Form: "luke"}, {Form: "ne"},
+	{Form: "fpi"}, {Form: "mì", Loose: map[string]string{"mi": "mì"}},
+	{Form: "lok"},
+	{Form: "mìkam", Loose: map[string]string{"mikam": "mìkam"}}, {Form: "kam"},
+	{Form: "ken"}, {Form: "sìn", Loose: map[string]string{"sin": "sìn"}}, {Form: "talun"},
+	{Form: "äo", Hyphen: []string{"ä", "e"}}, {Form: "eo", Hyphen: []string{"e"}}, {Form: "io", Hyphen: []string{"i"}},
+	{Form: "uo", Hyphen: []string{"u"}}, {Form: "ro"}, {Form: "to"}, {Form: "sko"},
+	{Form: "tafkip"}, {Form: "takip"}, {Form: "fkip"}, {Form: "kip"},
+	{Form: "ftu"}, {Form: "hu"}, {Form: "sru"},
+	{Form: "pximaw"}, {Form: "maw"}, {Form: "pxaw"}, {Form: "few"}, {Form: "raw"},
+	{Form: "vay"}, {Form: "kay"},
+}
+
+func adpositionAffixes() (affixes []Affix) {
+	for _, adposition := range adpositions {
+		adposition.Kind = SuffixAffix
+		adposition.Slot = SlotAdposition
+		adposition.PartsOfSpeech = nouns
+		adposition.Productivity = Productive
+		affixes = append(affixes, adposition)
+	}
+	return
+}
+
+// ListAffixes lists the affixes of the catalog, in the order they go on a word.
+// An empty kind, pos or productivity lists all of them.
+// pos can be a part of speech of the dictionary like "vtr." or "adv., n.",
+// an affix of "n." goes on pronouns and proper nouns, too.
+func ListAffixes(kind AffixKind, pos string, productivity Productivity) (affixes []Affix) {
+	for _, affix := range affixCatalog {
+		if kind != "" && affix.Kind != kind {
+			continue
+		}
+		if productivity != AnyProductivity && affix.Productivity != productivity {
+			continue
+		}
+		if pos != "" && !affix.goesOnPartOfSpeech(pos) {
+			continue
+		}
+		affixes = append(affixes, affix.clone())
+	}
+	return
+}
+
+// Definition is the gloss of the affix in the language, or in one of its fallbacks (see FallbackChain)
+func (a Affix) Definition(lang string) string {
+	for _, code := range FallbackChain(lang) {
+		if gloss, ok := a.Gloss[code]; ok {
+			return gloss
+		}
+	}
+	return ""
+}
+
+// Spellings are the form, the allomorphs, the variants and the reef and loose spellings of the affix
+func (a Affix) Spellings() []string {
+	spellings := slices.Concat([]string{a.Form}, a.Allomorphs, a.Variants)
+	spellings = append(spellings, sortedKeys(a.Reef)...)
+	return append(spellings, sortedKeys(a.Loose)...)
+}
+
+func (a Affix) goesOnPartOfSpeech(pos string) bool {
+	if len(a.PartsOfSpeech) == 0 {
+		return true
+	}
+	for _, part := range strings.Split(pos, ",") {
+		part = strings.TrimSpace(part)
+		if slices.Contains(a.PartsOfSpeech, part) || a.goesOn(partOfSpeechKind(part)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether it goes on the kind of word, see partOfSpeechKind
+func (a Affix) goesOn(kind wordKind) bool {
+	if len(a.PartsOfSpeech) == 0 {
+		return true
+	}
+	for _, pos := range a.PartsOfSpeech {
+		if kind != kindOther && partOfSpeechKind(pos) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy the lists and maps, so the catalog can't be changed from outside
+func (a Affix) clone() Affix {
+	a.PartsOfSpeech = slices.Clone(a.PartsOfSpeech)
+	a.Allomorphs = slices.Clone(a.Allomorphs)
+	a.Variants = slices.Clone(a.Variants)
+	a.Hyphen = slices.Clone(a.Hyphen)
+	a.Reef = cloneMap(a.Reef)
+	a.Loose = cloneMap(a.Loose)
+	a.Gloss = cloneMap(a.Gloss)
+	return a
+}
+
+func cloneMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
+
+func sortedKeys(m map[string]string) (keys []string) {
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// The affix with the form, the productive one if there are two like tì
+func findAffix(kind AffixKind, form string) (found Affix, ok bool) {
+	for _, affix := range affixCatalog {
+		if affix.Kind == kind && affix.Form == form {
+			if affix.Productivity == Productive {
+				return affix, true
+			}
+			found, ok = affix, true
+		}
+	}
+	return
+}
+
+// The productive affixes of a slot the deconjugator looks for, with all their spellings, except the forms in except
+func slotSpellings(kind AffixKind, slot AffixSlot, except ...string) (spellings []string) {
+	for _, affix := range affixCatalog {
+		if affix.Kind == kind && affix.Slot == slot && affix.Productivity == Productive && !slices.Contains(except, affix.Form) {
+			spellings = append(spellings, affix.Spellings()...)
+		}
+	}
+	return
+}
+
+// slotSpellings as a set
+func slotSpellingSet(kind AffixKind, slot AffixSlot) map[string]bool {
+	set := map[string]bool{}
+	for _, spelling := range slotSpellings(kind, slot) {
+		set[spelling] = true
+	}
+	return set
+}
+
+// All infixes by their first letter
+func infixesByVowel() map[rune][]string {
+	byVowel := map[rune][]string{}
+	for _, slot := range []AffixSlot{SlotPrefirst, SlotFirst, SlotSecond} {
+		for _, infix := range slotSpellings(InfixAffix, slot) {
+			vowel := []rune(infix)[0]
+			byVowel[vowel] = append(byVowel[vowel], infix)
+		}
+	}
+	// The longer infixes go first, äpeyk must not be taken for äp
+	for _, spellings := range byVowel {
+		slices.SortStableFunc(spellings, func(a, b string) int { return len(b) - len(a) })
+	}
+	return byVowel
+}
+
+// The reef or loose spellings of all affixes, and the forms they stand for
+func catalogSpellings(reef bool) map[string]string {
+	spellings := map[string]string{}
+	for _, affix := range affixCatalog {
+		fixes := affix.Loose
+		if reef {
+			fixes = affix.Reef
+		}
+		for spelling, form := range fixes {
+			spellings[spelling] = form
+		}
+	}
+	return spellings
+}
+
+// The affixes that are written with a hyphen after some vowels, and those vowels
+func hyphenSuffixes() map[string][]string {
+	hyphens := map[string][]string{}
+	for _, affix := range affixCatalog {
+		if len(affix.Hyphen) > 0 {
+			hyphens[affix.Form] = affix.Hyphen
+		}
+	}
+	return hyphens
+}
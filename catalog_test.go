@@ -0,0 +1,123 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func affixForms(affixes []Affix) (forms []string) {
+	for _, affix := range affixes {
+		forms = append(forms, affix.Form)
+	}
+	return
+}
+
+func TestListAffixes(t *testing.T) {
+	tests := []struct {
+		kind         AffixKind
+		pos          string
+		productivity Productivity
+		want         []string
+		wantNot      []string
+	}{
+		{PrefixAffix, "vtr.", Productive, []string{"a", "tsuk", "ketsuk", "tì"}, []string{"pe", "ay", "fne", "sä"}},
+		{PrefixAffix, "pn.", AnyProductivity, []string{"fì", "tsa", "pe", "ay", "fne"}, []string{"a", "tsuk", "tì"}},
+		{PrefixAffix, "", Unproductive, []string{"tì", "le", "sä", "ke"}, []string{"a", "pe"}},
+		{InfixAffix, "", AnyProductivity, []string{"äp", "ol", "er", "ei", "äng"}, []string{"ep", "ap"}},
+		{SuffixAffix, "adj.", AnyProductivity, []string{"a", "sì"}, []string{"l", "mì", "tswo"}},
+		{SuffixAffix, "adv., n.", AnyProductivity, []string{"l", "mì", "tsyìp", "sì"}, []string{"tswo"}},
+	}
+	for _, tt := range tests {
+		got := affixForms(ListAffixes(tt.kind, tt.pos, tt.productivity))
+		for _, form := range tt.want {
+			if !slices.Contains(got, form) {
+				t.Errorf("ListAffixes(%q, %q, %q) = %v, want %s in it", tt.kind, tt.pos, tt.productivity, got, form)
+			}
+		}
+		for _, form := range tt.wantNot {
+			if slices.Contains(got, form) {
+				t.Errorf("ListAffixes(%q, %q, %q) = %v, want no %s in it", tt.kind, tt.pos, tt.productivity, got, form)
+			}
+		}
+	}
+
+	if got := len(ListAffixes("", "", AnyProductivity)); got != len(affixCatalog) {
+		t.Errorf("ListAffixes lists %d affixes, want all %d", got, len(affixCatalog))
+	}
+}
+
+func TestListAffixesCopies(t *testing.T) {
+	affix := ListAffixes(SuffixAffix, "", Productive)[0]
+	affix.PartsOfSpeech[0] = "xyz"
+	affix.Gloss["en"] = "xyz"
+	if got := ListAffixes(SuffixAffix, "", Productive)[0]; reflect.DeepEqual(got, affix) {
+		t.Errorf("changing an affix of ListAffixes changed the catalog: %+v", got)
+	}
+}
+
+func TestAffixDefinition(t *testing.T) {
+	affix, ok := findAffix(PrefixAffix, "ay")
+	if !ok {
+		t.Fatal("no prefix ay")
+	}
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"en", affix.Gloss["en"]},
+		{"de", affix.Gloss["de"]},
+		{"hu", affix.Gloss["en"]},
+	}
+	for _, tt := range tests {
+		if got := affix.Definition(tt.lang); got != tt.want || got == "" {
+			t.Errorf("Definition(%s) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+
+	adposition, _ := findAffix(SuffixAffix, "mì")
+	if got := adposition.Definition("en"); got != "" {
+		t.Errorf("Definition of mì = %q, want none", got)
+	}
+}
+
+// What the catalog lists is what the deconjugator looks for
+func TestAffixSpellings(t *testing.T) {
+	for _, affix := range ListAffixes("", "", Productive) {
+		for _, spelling := range affix.Spellings() {
+			_, reef := unreefFixes[spelling]
+			_, loose := unstrictFixes[spelling]
+			var found bool
+			switch {
+			case affix.Kind == PrefixAffix && affix.Slot != SlotDeterminer && affix.Slot != SlotAttributive && affix.Slot != SlotGerund:
+				found = slices.Contains(slices.Concat(prefixes1lenition, stemPrefixes, verbPrefixes), spelling)
+			case affix.Kind == InfixAffix:
+				found = prefirstMap[spelling] || firstMap[spelling] || secondMap[spelling]
+			case affix.Slot == SlotCase || affix.Slot == SlotAdposition:
+				found = slices.Contains(adposuffixes, spelling)
+			default:
+				continue
+			}
+			if !found && !reef && !loose {
+				t.Errorf("the deconjugator doesn't know %s of %s", spelling, affix.Form)
+			}
+		}
+	}
+
+	for spelling, want := range map[string]string{"im": "ìm", "ily": "ìly", "tsyip": "tsyìp", "fi": "fì", "ilä": "ìlä"} {
+		if got := unstrictFixes[spelling]; got != want {
+			t.Errorf("unstrictFixes[%s] = %q, want %q", spelling, got, want)
+		}
+	}
+	// variants are accepted in strict mode, too
+	for _, spelling := range []string{"iyev"} {
+		if _, loose := unstrictFixes[spelling]; loose || !firstMap[spelling] {
+			t.Errorf("%s is not a strict first position infix", spelling)
+		}
+	}
+	for spelling, want := range map[string]string{"ep": "äp", "ye": "yä", "ìle": "ìlä"} {
+		if got := unreefFixes[spelling]; got != want {
+			t.Errorf("unreefFixes[%s] = %q, want %q", spelling, got, want)
+		}
+	}
+}
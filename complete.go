@@ -0,0 +1,187 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. complete.go completes the start of a word.
+package fwew_lib
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Completion is a word that starts with what was typed
+type Completion struct {
+	Text string
+	// FromNavi for a Na'vi headword, ToNavi for a word of the definitions
+	Direction Direction
+}
+
+// One word that can be completed
+type completionEntry struct {
+	// what the prefix has to match, lowercase
+	key  string
+	text string
+	// position in alphabetical order
+	rank int
+}
+
+// Sorted by key, so all keys with the same prefix are next to each other
+type completionIndex struct {
+	navi []completionEntry
+	// by language code, built when first asked for
+	natlang map[string][]completionEntry
+}
+
+// Complete lists the Na'vi headwords starting with prefix in alphabetical order,
+// followed by the words of the lang definitions starting with it.
+// An empty lang only completes Na'vi, a lang without definitions completes English.
+// At most limit completions are returned.
+func (d *Dictionary) Complete(prefix string, lang string, limit int) (completions []Completion) {
+	prefix = completionKey(strings.TrimLeft(prefix, " "))
+	if limit <= 0 {
+		return
+	}
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	navi, natlang := d.completionEntries(lang)
+
+	for _, entry := range completeEntries(navi, prefix, limit) {
+		completions = append(completions, Completion{Text: entry.text, Direction: FromNavi})
+	}
+	for _, entry := range completeEntries(natlang, prefix, limit-len(completions)) {
+		completions = append(completions, Completion{Text: entry.text, Direction: ToNavi})
+	}
+	return
+}
+
+// The indexes for Complete, built from the caches if needed.  d.lock must be held for reading.
+func (d *Dictionary) completionEntries(lang string) (navi []completionEntry, natlang []completionEntry) {
+	d.completionLock.Lock()
+	defer d.completionLock.Unlock()
+
+	if d.completions == nil {
+		d.completions = &completionIndex{
+			navi:    naviCompletions(d.words),
+			natlang: map[string][]completionEntry{},
+		}
+	}
+	if lang == "" {
+		return d.completions.navi, nil
+	}
+
+	lang = d.searchLanguage(lang)
+	natlang, ok := d.completions.natlang[lang]
+	if !ok {
+		natlang = natlangCompletions(d.hash2Parenthesis[lang])
+		d.completions.natlang[lang] = natlang
+	}
+	return d.completions.navi, natlang
+}
+
+func naviCompletions(words []Word) []completionEntry {
+	seen := map[string]bool{}
+	var entries []completionEntry
+	for _, word := range words {
+		key := completionKey(strings.ReplaceAll(word.Navi, "+", ""))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		entries = append(entries, completionEntry{key: key, text: strings.ReplaceAll(word.Navi, "+", "")})
+	}
+
+	// Na'vi alphabetical order, like AlphabetizeHelper, but every word is only compressed once
+	alphabetKeys := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		alphabetKeys[entry.key] = alphabetKey(entry.key)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return alphabetKeys[entries[i].key] < alphabetKeys[entries[j].key]
+	})
+	for i := range entries {
+		entries[i].rank = i
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	return entries
+}
+
+func natlangCompletions(terms map[string][]string) []completionEntry {
+	entries := make([]completionEntry, 0, len(terms))
+	for term := range terms {
+		if term != "" {
+			entries = append(entries, completionEntry{key: term, text: term})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	for i := range entries {
+		entries[i].rank = i
+	}
+	return entries
+}
+
+// The first limit entries starting with prefix, in alphabetical order
+func completeEntries(entries []completionEntry, prefix string, limit int) []completionEntry {
+	if limit <= 0 {
+		return nil
+	}
+
+	start := sort.Search(len(entries), func(i int) bool {
+		return entries[i].key >= prefix
+	})
+	end := start
+	for end < len(entries) && strings.HasPrefix(entries[end].key, prefix) {
+		end++
+	}
+
+	// keep the limit best ranks, sorting everything would be slower for short prefixes
+	found := make([]completionEntry, 0, limit+1)
+	for _, entry := range entries[start:end] {
+		if len(found) == limit && entry.rank > found[limit-1].rank {
+			continue
+		}
+		i := sort.Search(len(found), func(i int) bool {
+			return found[i].rank > entry.rank
+		})
+		found = slices.Insert(found, i, entry)
+		if len(found) > limit {
+			found = found[:limit]
+		}
+	}
+	return found
+}
+
+func completionKey(text string) string {
+	text = strings.ToLower(text)
+	text = strings.ReplaceAll(text, "’", "'")
+	return strings.ReplaceAll(text, "‘", "'")
+}
+
+// Sorts like AlphabetizeHelper when compared as strings
+func alphabetKey(navi string) string {
+	compressed := []rune(strings.ReplaceAll(compress(navi), "-", ""))
+	key := make([]byte, len(compressed))
+	for i, r := range compressed {
+		// letterMap starts at -1 for spaces
+		key[i] = byte(letterMap[r] + 2)
+	}
+	return string(key)
+}
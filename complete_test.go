@@ -0,0 +1,111 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "tsaw", "ˈt͡saw", "NULL", "pn.", "1", "tsaw", "NULL", "that"),
+		testDictRow("3", "tìtxen si", "tɪ.ˈt'ɛn ˈsi", "NULL", "vin.", "2", "tì-txen si", "NULL", "wake up"),
+		testDictRow("4", "tsmukan", "ˈt͡smu.kan", "NULL", "n.", "1", "tsmu-kan", "NULL", "brother (one's peer)"),
+		testDictRow("5", "tìng", "ˈtɪŋ", "NULL", "vtr.", "1", "tìng", "NULL", "give"),
+		testDictRow("6", "tel", "ˈtɛl", "NULL", "vtr.", "1", "tel", "NULL", "receive, get, take"),
+		testDictRow("7", "Tute", "ˈtu.tɛ", "NULL", "n.", "1", "Tu-te", "NULL", "people"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	texts := func(completions []Completion) (texts []string) {
+		for _, completion := range completions {
+			texts = append(texts, string(completion.Direction)+":"+completion.Text)
+		}
+		return
+	}
+
+	tests := []struct {
+		prefix string
+		lang   string
+		limit  int
+		want   []string
+	}{
+		// Na'vi order: ts is a letter of its own after t, and ì comes before u
+		{"t", "", 10, []string{"navi:tel", "navi:tìng", "navi:tìtxen si", "navi:tute", "navi:tsaw", "navi:tsmukan"}},
+		{"T", "", 2, []string{"navi:tel", "navi:tìng"}},
+		{"tìtxen ", "", 10, []string{"navi:tìtxen si"}},
+		{"ts", "en", 10, []string{"navi:tsaw", "navi:tsmukan"}},
+		{"pe", "en", 10, []string{"natlang:peer", "natlang:people", "natlang:person"}},
+		{"t", "en", 7, []string{"navi:tel", "navi:tìng", "navi:tìtxen si", "navi:tute", "navi:tsaw", "navi:tsmukan", "natlang:take"}},
+		{"pe", "xx", 1, []string{"natlang:peer"}},
+		{"x", "en", 10, nil},
+		{"t", "en", 0, nil},
+	}
+	for _, tt := range tests {
+		if got := texts(d.Complete(tt.prefix, tt.lang, tt.limit)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Complete(%q, %q, %d) = %v, want %v", tt.prefix, tt.lang, tt.limit, got, tt.want)
+		}
+	}
+
+	// a reload builds a new index
+	d.SetSource(NewMemorySource(testWords(testDictRow("8", "taron", "ˈta.ɾon", "NULL", "vtr.", "1", "ta-ron", "NULL", "hunt"))))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := texts(d.Complete("t", "en", 10)); !reflect.DeepEqual(got, []string{"navi:taron"}) {
+		t.Errorf("Complete() after a reload = %v", got)
+	}
+}
+
+func TestCompleteWhileLoading(t *testing.T) {
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if completions := d.Complete("tu", "en", 5); len(completions) != 1 {
+					t.Errorf("Complete() = %v", completions)
+					return
+				}
+			}
+		}()
+	}
+	for range 10 {
+		if err := d.Load(); err != nil {
+			t.Error(err)
+		}
+	}
+	wg.Wait()
+}
+
+func BenchmarkComplete(b *testing.B) {
+	// 3000 words all starting with t
+	var rows []string
+	for i := range 3000 {
+		navi := "t" + string(rune('a'+i%26)) + string(rune('a'+i/26%26)) + "e"
+		rows = append(rows, testDictRow(strconv.Itoa(i), navi, "NULL", "NULL", "n.", "1", navi, "NULL", "word"))
+	}
+	words := testWords(rows...)
+	d := NewDictionary(NewMemorySource(words))
+	if err := d.Load(); err != nil {
+		b.Fatal(err)
+	}
+	d.Complete("t", "en", 10)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Complete("t", "en", 10)
+	}
+}
@@ -0,0 +1,585 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. conjugate.go puts affixes on a word, the other way round than affixes_hash.go.
+package fwew_lib
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Case is the case of a noun, see ConjugationSpec
+type Case string
+
+const (
+	Subjective Case = ""
+	Agentive   Case = "agentive"
+	Patientive Case = "patientive"
+	Dative     Case = "dative"
+	Genitive   Case = "genitive"
+	Topical    Case = "topical"
+)
+
+// ConjugationSpec is what to put on a word with Conjugate.
+// The affixes can be given in any order, they are put in their places around the word.
+// Reef or loose forms (ep, ye, fi, ...) are understood as their forest ones.
+type ConjugationSpec struct {
+	// e.g. "ay", "fì", "tsa", "pe", "fne", "a", "tsuk" or "tì".  fì and ay become fay and so on.
+	Prefixes []string
+	// Verb infixes, each goes into its place of the InfixLocations.  äp and eyk become äpeyk.
+	Infixes []string
+	// e.g. "tsyìp", "o", "pe", "sì", "a", "yu" or "tswo", but no case endings or adpositions
+	Suffixes []string
+	// The case ending, in the form that fits the word
+	Case Case
+	// e.g. "mì" or "kip".  A noun can have a Case or an Adposition, not both.
+	Adposition string
+}
+
+// What a conjugated word is
+type wordKind int
+
+const (
+	kindOther wordKind = iota
+	kindNoun
+	kindVerb
+	kindAdjective
+	kindNumber
+)
+
+func partOfSpeechKind(pos string) wordKind {
+	switch {
+	case strings.HasPrefix(pos, "v") || strings.HasPrefix(pos, svin):
+		return kindVerb
+	case pos == n || pos == pn || pos == propN || pos == inter:
+		return kindNoun
+	case pos == adj:
+		return kindAdjective
+	case pos == num:
+		return kindNumber
+	}
+	return kindOther
+}
+
+// The places of the prefixes, from the outermost one in
+const (
+	prefixAttributive = iota // a, nì
+	prefixVerb               // tsuk, ketsuk
+	prefixDeterminer         // fì, tsa, pe, fra
+	prefixNumber             // ay, me, pxe
+	prefixStem               // fne, sna, munsna
+	prefixGerund             // tì
+)
+
+// The places of the suffixes, from the innermost one out
+const (
+	suffixVerb        = iota // yu, tswo, tseng
+	suffixStem               // tsyìp, fkeyk
+	suffixSome               // o
+	suffixDeterminer         // pe
+	suffixAttributive        // a
+	suffixCase               // case endings and adpositions
+	suffixAnd                // sì
+)
+
+// The places of the slots of the catalog, see affixCatalog
+var conjugationPrefixSlots = map[AffixSlot]int{
+	SlotAttributive: prefixAttributive, SlotVerb: prefixVerb, SlotDeterminer: prefixDeterminer,
+	SlotNumber: prefixNumber, SlotStem: prefixStem, SlotGerund: prefixGerund,
+}
+var conjugationSuffixSlots = map[AffixSlot]int{
+	SlotVerb: suffixVerb, SlotStem: suffixStem, SlotSome: suffixSome,
+	SlotDeterminer: suffixDeterminer, SlotAttributive: suffixAttributive, SlotAnd: suffixAnd,
+}
+
+// fì and ay are fay, ...
+var prefixContractions = map[[2]string]string{
+	{"fì", "ay"}: "fay", {"tsa", "ay"}: "tsay", {"pe", "ay"}: "pay", {"fra", "ay"}: "fray",
+	{"pe", "me"}: "pem", {"pe", "pxe"}: "pep",
+}
+
+// The prefixes that lenite the word after them, and the contractions with them
+var lenitingPrefixes = slices.Concat(lenitingAffixes(), prefixes1NounsLenition, []string{"tsay", "fray", "pem", "pep"})
+
+// Verbs that change with some second position infixes
+var irregularInfixLocations = map[string]struct {
+	second    []string
+	locations string
+}{
+	// zen<ats>eke and zen<uy>eke, see deconjugateHelper
+	"z<0><1>en<2>ke": {[]string{"ats", "uy"}, "z<0><1>en<2>eke"},
+}
+
+// Words with a case form that isn't made by the rules
+var irregularCaseForms = map[string]map[Case][]string{
+	"oe":        {Genitive: {"oeyä", "oey"}},
+	"nga":       {Genitive: {"ngeyä", "ngey"}},
+	"tsaw":      {Genitive: {"tseyä"}},
+	"omatikaya": {Genitive: {"omatikayaä"}},
+}
+
+func lenitingAffixes() (prefixes []string) {
+	for _, affix := range affixCatalog {
+		if affix.Lenition {
+			prefixes = append(prefixes, affix.Form)
+		}
+	}
+	return
+}
+
+// Conjugate puts the affixes of the spec on the word and returns every form it can have, the usual one first.
+// More than one form comes from case endings with more than one form (tutet and tuteti)
+// and short plurals (aysute and sute).
+// It fails with InvalidConjugation if the word can't have these affixes together.
+func Conjugate(word Word, spec ConjugationSpec) ([]string, error) {
+	spec = spec.forest()
+
+	var firstErr error
+	for _, pos := range strings.Split(word.PartOfSpeech, ",") {
+		forms, err := conjugate(word, strings.TrimSpace(pos), spec)
+		if err == nil {
+			return forms, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// The spec with the forest form of every affix
+func (spec ConjugationSpec) forest() ConjugationSpec {
+	fix := func(affix string) string {
+		affix = strings.ToLower(strings.TrimSpace(affix))
+		if forest, ok := unreefFixes[affix]; ok {
+			affix = forest
+		}
+		if strict, ok := unstrictFixes[affix]; ok {
+			affix = strict
+		}
+		return affix
+	}
+	forest := ConjugationSpec{Case: spec.Case, Adposition: fix(spec.Adposition)}
+	for _, prefix := range spec.Prefixes {
+		forest.Prefixes = append(forest.Prefixes, fix(prefix))
+	}
+	for _, infix := range spec.Infixes {
+		forest.Infixes = append(forest.Infixes, fix(infix))
+	}
+	for _, suffix := range spec.Suffixes {
+		forest.Suffixes = append(forest.Suffixes, fix(suffix))
+	}
+	return forest
+}
+
+func conjugationError(format string, a ...any) error {
+	return InvalidConjugation.wrap(fmt.Errorf(format, a...))
+}
+
+func conjugate(word Word, pos string, spec ConjugationSpec) ([]string, error) {
+	kind := partOfSpeechKind(pos)
+	navi := strings.ToLower(strings.ReplaceAll(word.Navi, "+", ""))
+
+	prefixes := map[int]string{}
+	for _, prefix := range spec.Prefixes {
+		affix, ok := findAffix(PrefixAffix, prefix)
+		slot, placed := conjugationPrefixSlots[affix.Slot]
+		if !ok || !placed {
+			if ok && affix.Productivity == Unproductive {
+				return nil, conjugationError("the prefix %s isn't productive", prefix)
+			}
+			return nil, conjugationError("unknown prefix %s", prefix)
+		}
+		if !affix.goesOn(kind) && !nominalized(kind, spec, affix) {
+			return nil, conjugationError("%s can't have the prefix %s", word.Navi, prefix)
+		}
+		if other, ok := prefixes[slot]; ok {
+			return nil, conjugationError("the prefixes %s and %s don't go together", other, prefix)
+		}
+		prefixes[slot] = prefix
+	}
+
+	suffixes := map[int]string{}
+	for _, suffix := range spec.Suffixes {
+		affix, ok := findAffix(SuffixAffix, suffix)
+		slot, placed := conjugationSuffixSlots[affix.Slot]
+		if !ok || !placed {
+			return nil, conjugationError("unknown suffix %s", suffix)
+		}
+		if other, ok := suffixes[slot]; ok {
+			return nil, conjugationError("the suffixes %s and %s don't go together", other, suffix)
+		}
+		suffixes[slot] = suffix
+	}
+	if spec.Adposition != "" {
+		if spec.Case != Subjective {
+			return nil, conjugationError("a case and the adposition %s don't go together", spec.Adposition)
+		}
+		if affix, ok := findAffix(SuffixAffix, spec.Adposition); !ok || affix.Slot != SlotAdposition {
+			return nil, conjugationError("unknown adposition %s", spec.Adposition)
+		}
+	}
+	if err := checkConjugation(word, kind, spec, prefixes, suffixes); err != nil {
+		return nil, err
+	}
+
+	stem := navi
+	if kind == kindVerb {
+		var err error
+		stem, err = placeInfixes(word, spec.Infixes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the suffixes before the case
+	for slot := suffixVerb; slot < suffixCase; slot++ {
+		if suffix, ok := suffixes[slot]; ok {
+			stem = appendSuffix(stem, suffix)
+		}
+	}
+
+	forms := []string{stem}
+	if spec.Case != Subjective || spec.Adposition != "" {
+		// some nouns change before case endings, see weirdNounSuffixes
+		caseStem := stem
+		if len(suffixes) == 0 || (len(suffixes) == 1 && suffixes[suffixAnd] != "") {
+			if weird := weirdStem(navi); weird != "" && spec.Case != Subjective {
+				caseStem = weird
+			}
+		}
+		endings, err := caseForms(word, pos, caseStem, spec)
+		if err != nil {
+			return nil, err
+		}
+		forms = endings
+	}
+
+	if suffix, ok := suffixes[suffixAnd]; ok {
+		for i := range forms {
+			forms[i] = appendSuffix(forms[i], suffix)
+		}
+	}
+
+	var result, short []string
+	for _, form := range forms {
+		result = append(result, prependPrefixes(form, prefixes))
+		// short plurals only have the lenition of ay
+		if len(prefixes) == 1 && prefixes[prefixNumber] == "ay" && leniteStart(form) != form {
+			short = append(short, leniteStart(form))
+		}
+	}
+	return append(result, short...), nil
+}
+
+// Verbs with yu, tswo or tseng and gerunds are nouns, so they can have the prefixes of nouns
+func nominalized(kind wordKind, spec ConjugationSpec, prefix Affix) bool {
+	if kind != kindVerb || !prefix.goesOn(kindNoun) {
+		return false
+	}
+	return implContainsAny(spec.Suffixes, verbSuffixes) || slices.Contains(spec.Prefixes, "tì")
+}
+
+// The rules about which affixes go together, the same the deconjugator follows
+func checkConjugation(word Word, kind wordKind, spec ConjugationSpec, prefixes map[int]string, suffixes map[int]string) error {
+	nominal := kind == kindNoun || (kind == kindVerb && (suffixes[suffixVerb] != "" || prefixes[prefixGerund] != ""))
+
+	for slot, suffix := range suffixes {
+		var ok bool
+		switch slot {
+		case suffixVerb:
+			ok = kind == kindVerb
+		case suffixStem, suffixSome, suffixDeterminer:
+			ok = nominal
+		case suffixAttributive:
+			ok = kind == kindAdjective || kind == kindNumber || kind == kindVerb
+		case suffixAnd:
+			ok = true
+		}
+		if !ok {
+			return conjugationError("%s can't have the suffix %s", word.Navi, suffix)
+		}
+	}
+	if (spec.Case != Subjective || spec.Adposition != "") && !nominal {
+		return conjugationError("%s can't have a case or adposition", word.Navi)
+	}
+	if spec.Case != Subjective && suffixes[suffixAttributive] != "" {
+		return conjugationError("a case and the suffix a don't go together")
+	}
+
+	// a- and -a, and nì- without anything else
+	if prefixes[prefixAttributive] == "a" && suffixes[suffixAttributive] != "" {
+		return conjugationError("the prefix a and the suffix a don't go together")
+	}
+	if prefixes[prefixAttributive] == "nì" && (len(prefixes) > 1 || len(suffixes) > 0) {
+		return conjugationError("the prefix nì doesn't go with other affixes")
+	}
+
+	if kind != kindVerb && len(spec.Infixes) > 0 {
+		return conjugationError("%s can't have infixes", word.Navi)
+	}
+	if kind == kindVerb {
+		participle := implContainsAny(spec.Infixes, []string{"us", "awn"})
+		if len(spec.Infixes) > 0 && (prefixes[prefixVerb] != "" || suffixes[suffixVerb] != "") {
+			return conjugationError("%s%s can't have infixes", prefixes[prefixVerb], suffixes[suffixVerb])
+		}
+		if prefixes[prefixGerund] != "" && !slices.Equal(spec.Infixes, []string{"us"}) {
+			return conjugationError("the prefix tì needs the infix us and no other")
+		}
+		if (prefixes[prefixAttributive] == "a" || suffixes[suffixAttributive] == "a") && !participle && prefixes[prefixVerb] == "" {
+			return conjugationError("only participles can have the prefix or suffix a")
+		}
+		if prefixes[prefixAttributive] == "nì" {
+			return conjugationError("%s can't have the prefix nì", word.Navi)
+		}
+	}
+
+	// Avoid fìfìtseng, zeykeyko and peupe
+	if forbidden, ok := productiveCompounds[strings.ToLower(word.Navi)]; ok {
+		var used []string
+		for _, prefix := range prefixes {
+			used = append(used, prefix)
+		}
+		if contraction, ok := prefixContractions[[2]string{prefixes[prefixDeterminer], prefixes[prefixNumber]}]; ok {
+			used = append(used, contraction)
+		}
+		if implContainsAny(used, forbidden[0]) || implContainsAny(spec.Infixes, forbidden[1]) ||
+			implContainsAny(spec.Suffixes, forbidden[2]) || implContainsAny([]string{spec.Adposition}, forbidden[2]) {
+			return conjugationError("%s already has these affixes", word.Navi)
+		}
+	}
+	return nil
+}
+
+// Put the infixes into the InfixLocations of the verb
+func placeInfixes(word Word, infixList []string) (string, error) {
+	navi := strings.ToLower(strings.ReplaceAll(word.Navi, "+", ""))
+	if len(infixList) == 0 {
+		return navi, nil
+	}
+	if !hasInfixLocations(word) {
+		return "", conjugationError("%s has no place for infixes", word.Navi)
+	}
+	locations := word.InfixLocations
+
+	// äp and eyk go together as äpeyk
+	if slices.Contains(infixList, "äp") && slices.Contains(infixList, "eyk") {
+		infixList = slices.DeleteFunc(slices.Clone(infixList), func(infix string) bool {
+			return infix == "äp" || infix == "eyk"
+		})
+		infixList = append(infixList, "äpeyk")
+	}
+
+	slots := []string{"", "", ""}
+	for _, infix := range infixList {
+		placed, newSlots := verifyInfix(slots, infix)
+		if !placed {
+			if prefirstMap[infix] || firstMap[infix] || secondMap[infix] {
+				return "", conjugationError("the infix %s doesn't go with the others", infix)
+			}
+			return "", conjugationError("unknown infix %s", infix)
+		}
+		slots = newSlots
+	}
+
+	// participles only have the first infixes, and no äp without eyk
+	if slots[1] == "us" || slots[1] == "awn" {
+		if slots[2] != "" {
+			return "", conjugationError("the participle %s doesn't go with %s", slots[1], slots[2])
+		}
+		if slots[1] == "awn" && slots[0] == "äp" {
+			return "", conjugationError("the participle awn doesn't go with äp")
+		}
+	}
+
+	if irregular, ok := irregularInfixLocations[locations]; ok && slices.Contains(irregular.second, slots[2]) {
+		locations = irregular.locations
+	}
+
+	verb := strings.Replace(locations, "<0>", slots[0], 1)
+	verb = strings.Replace(verb, "<1>", slots[1], 1)
+	verb = strings.Replace(verb, "<2>", slots[2], 1)
+	// <ol>ll and <er>rr
+	verb = strings.Replace(verb, "olll", "ol", 1)
+	verb = strings.Replace(verb, "errr", "er", 1)
+	return strings.ToLower(verb), nil
+}
+
+// NULL or \N if the word has none
+func hasInfixLocations(word Word) bool {
+	return strings.Contains(word.InfixLocations, "<1>")
+}
+
+// The stem a noun has before a case ending, if it isn't the noun itself, see weirdNounSuffixes
+func weirdStem(navi string) (stem string) {
+	var stems []string
+	for weird, noun := range weirdNounSuffixes {
+		if noun == navi {
+			stems = append(stems, weird)
+		}
+	}
+	// the one spelled like the noun, not its loose spelling
+	sort.Slice(stems, func(i, j int) bool {
+		a, b := commonPrefix(stems[i], navi), commonPrefix(stems[j], navi)
+		if a != b {
+			return a > b
+		}
+		return stems[i] < stems[j]
+	})
+	if len(stems) > 0 {
+		stem = stems[0]
+	}
+	return
+}
+
+func commonPrefix(a string, b string) (length int) {
+	for length < len(a) && length < len(b) && a[length] == b[length] {
+		length++
+	}
+	return
+}
+
+// Put a suffix on a stem.  Some need a hyphen between the same vowels, like fya'o-o.
+func appendSuffix(stem string, suffix string) string {
+	if vowels, ok := vowelSuffixes[suffix]; ok && len(stem) > 0 {
+		for _, vowel := range vowels {
+			if strings.HasSuffix(stem, vowel) {
+				return stem + "-" + suffix
+			}
+		}
+	}
+	return stem + suffix
+}
+
+// The forms of the noun with its case ending or adposition
+func caseForms(word Word, pos string, stem string, spec ConjugationSpec) ([]string, error) {
+	navi := strings.ToLower(strings.ReplaceAll(word.Navi, "+", ""))
+	if spec.Adposition != "" {
+		return []string{appendSuffix(stem, spec.Adposition)}, nil
+	}
+
+	if irregular, ok := irregularCaseForms[navi][spec.Case]; ok && (stem == navi || stem == weirdStem(navi)) {
+		return slices.Clone(irregular), nil
+	}
+
+	// pronouns like po and sno have peyä and sneyä
+	if spec.Case == Genitive && pos == pn && (strings.HasSuffix(stem, "a") || strings.HasSuffix(stem, "o")) {
+		return []string{stem[:len(stem)-1] + "eyä"}, nil
+	}
+	// soaiä
+	if spec.Case == Genitive && strings.HasSuffix(stem, "ia") {
+		return []string{strings.TrimSuffix(stem, "a") + "ä"}, nil
+	}
+
+	var forms []string
+	for _, ending := range caseEndingsFor(stem, spec.Case) {
+		if forbidden, ok := productiveCompounds[navi]; ok && slices.Contains(forbidden[2], ending) {
+			continue
+		}
+		if verifyCaseEnding(stem, ending) {
+			forms = append(forms, stem+ending)
+		}
+	}
+	if len(forms) == 0 {
+		return nil, conjugationError("%s has no %s form", word.Navi, spec.Case)
+	}
+	return forms, nil
+}
+
+// The forms of the case ending that could fit the noun, verifyCaseEnding decides
+func caseEndingsFor(noun string, c Case) []string {
+	last := get_last_rune(noun, 1)
+	ending := ""
+	if len(noun) >= 2 {
+		ending = noun[len(noun)-2:]
+	}
+	diphthong := ending == "ay" || ending == "ey" || ending == "aw" || ending == "ew"
+	vowel := !diphthong && is_vowel(last)
+
+	switch c {
+	case Agentive:
+		if vowel {
+			return []string{"l"}
+		}
+		return []string{"ìl"}
+	case Patientive:
+		if vowel {
+			return []string{"t", "ti"}
+		}
+		if diphthong && last == 'y' {
+			return []string{"t", "ti", "it"}
+		}
+		return []string{"it", "ti"}
+	case Dative:
+		if vowel {
+			return []string{"r", "ru"}
+		}
+		if diphthong && last == 'w' {
+			return []string{"r", "ru", "ur"}
+		}
+		return []string{"ur", "ru"}
+	case Genitive:
+		if vowel && last != 'o' && last != 'u' {
+			return []string{"yä"}
+		}
+		return []string{"ä"}
+	case Topical:
+		if vowel || diphthong {
+			return []string{"ri"}
+		}
+		return []string{"ìri"}
+	}
+	return nil
+}
+
+// Put the prefixes in front of the word, from the inside out
+func prependPrefixes(word string, prefixes map[int]string) string {
+	// fì and ay are fay
+	if contraction, ok := prefixContractions[[2]string{prefixes[prefixDeterminer], prefixes[prefixNumber]}]; ok {
+		prefixes = maps.Clone(prefixes)
+		delete(prefixes, prefixDeterminer)
+		prefixes[prefixNumber] = contraction
+	}
+
+	form := word
+	for slot := prefixGerund; slot >= prefixAttributive; slot-- {
+		prefix, ok := prefixes[slot]
+		if !ok {
+			continue
+		}
+		if slices.Contains(lenitingPrefixes, prefix) {
+			form = leniteStart(form)
+		}
+		// tsa and atan are tsatan
+		if last := get_last_rune(prefix, 1); slot != prefixAttributive && is_vowel(last) {
+			form = strings.TrimPrefix(form, string(last))
+		}
+		form = prefix + form
+	}
+	return form
+}
+
+// Lenite the beginning of the word, see lenitionTable
+func leniteStart(word string) string {
+	for _, v := range lenitionTable {
+		if strings.HasPrefix(word, v[0]) {
+			return v[1] + strings.TrimPrefix(word, v[0])
+		}
+	}
+	return word
+}
@@ -0,0 +1,166 @@
+package fwew_lib
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func conjugateTestWords() map[string]Word {
+	words := map[string]Word{}
+	for _, word := range testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "'eylan", "ˈʔɛj.lan", "NULL", "n.", "1", "'ey-lan", "NULL", "friend"),
+		testDictRow("3", "kelku", "ˈkɛl.ku", "NULL", "n.", "1", "kel-ku", "NULL", "home"),
+		testDictRow("4", "fya'o", "ˈfja.ʔo", "NULL", "n.", "1", "fya-'o", "NULL", "way"),
+		testDictRow("5", "soaia", "so.ˈa.i.a", "NULL", "n.", "2", "so-a-i-a", "NULL", "family"),
+		testDictRow("6", "po", "po", "NULL", "pn.", "1", "po", "NULL", "he, she"),
+		testDictRow("7", "tsaw", "t͡saw", "NULL", "pn.", "1", "tsaw", "NULL", "that"),
+		testDictRow("8", "taron", "ˈta.ɾon", "t<0><1>ar<2>on", "vtr.", "1", "ta-ron", "t..ar.on", "hunt"),
+		testDictRow("9", "zenke", "ˈzɛn.kɛ", "z<0><1>en<2>ke", "vtr.", "1", "zen-ke", "z..en.ke", "must"),
+		testDictRow("10", "lor", "ˈloɾ", "NULL", "adj.", "1", "lor", "NULL", "beautiful"),
+		testDictRow("11", "fìtseng", "fɪ.ˈt͡sɛŋ", "NULL", "adv., n.", "2", "fì-tseng", "NULL", "here"),
+		testDictRow("12", "tsmukan", "ˈt͡smu.kan", "NULL", "n.", "1", "tsmu-kan", "NULL", "brother"),
+		testDictRow("13", "nari", "ˈna.ɾi", "NULL", "n.", "1", "na-ri", "NULL", "eye"),
+	) {
+		words[word.Navi] = word
+	}
+	return words
+}
+
+func TestConjugate(t *testing.T) {
+	words := conjugateTestWords()
+
+	tests := []struct {
+		navi string
+		spec ConjugationSpec
+		want []string
+	}{
+		{"tute", ConjugationSpec{}, []string{"tute"}},
+		{"tute", ConjugationSpec{Case: Agentive}, []string{"tutel"}},
+		{"tute", ConjugationSpec{Case: Patientive}, []string{"tutet", "tuteti"}},
+		{"tute", ConjugationSpec{Case: Dative}, []string{"tuter", "tuteru"}},
+		{"tute", ConjugationSpec{Case: Genitive}, []string{"tuteyä"}},
+		{"tute", ConjugationSpec{Case: Topical}, []string{"tuteri"}},
+		{"tute", ConjugationSpec{Prefixes: []string{"ay"}}, []string{"aysute", "sute"}},
+		{"tute", ConjugationSpec{Prefixes: []string{"ay"}, Case: Patientive}, []string{"aysutet", "aysuteti", "sutet", "suteti"}},
+		{"tute", ConjugationSpec{Prefixes: []string{"ay", "fì"}}, []string{"faysute"}},
+		{"tute", ConjugationSpec{Prefixes: []string{"pe"}}, []string{"pesute"}},
+		{"tute", ConjugationSpec{Prefixes: []string{"me"}, Case: Patientive}, []string{"mesutet", "mesuteti"}},
+		{"tute", ConjugationSpec{Suffixes: []string{"sì"}, Case: Agentive}, []string{"tutelsì"}},
+		{"tute", ConjugationSpec{Adposition: "mì"}, []string{"tutemì"}},
+		{"tute", ConjugationSpec{Prefixes: []string{"fne"}, Suffixes: []string{"tsyìp"}}, []string{"fnetutetsyìp"}},
+		{"'eylan", ConjugationSpec{Prefixes: []string{"pxe"}}, []string{"pxeylan"}},
+		{"'eylan", ConjugationSpec{Prefixes: []string{"ay"}}, []string{"ayeylan", "eylan"}},
+		{"'eylan", ConjugationSpec{Case: Agentive}, []string{"'eylanìl"}},
+		{"'eylan", ConjugationSpec{Case: Patientive}, []string{"'eylanit", "'eylanti"}},
+		{"'eylan", ConjugationSpec{Case: Dative}, []string{"'eylanur"}},
+		{"'eylan", ConjugationSpec{Case: Genitive}, []string{"'eylanä"}},
+		{"'eylan", ConjugationSpec{Case: Topical}, []string{"'eylanìri"}},
+		{"tsmukan", ConjugationSpec{Prefixes: []string{"tsa"}, Case: Genitive}, []string{"tsatsmukanä"}},
+		{"kelku", ConjugationSpec{Case: Genitive}, []string{"kelkuä"}},
+		{"nari", ConjugationSpec{Case: Genitive}, []string{"nariyä"}},
+		{"fya'o", ConjugationSpec{Suffixes: []string{"o"}}, []string{"fya'o-o"}},
+		{"soaia", ConjugationSpec{Case: Genitive}, []string{"soaiä"}},
+		{"po", ConjugationSpec{Case: Genitive}, []string{"peyä"}},
+		{"tsaw", ConjugationSpec{Case: Agentive}, []string{"tsal"}},
+		{"tsaw", ConjugationSpec{Case: Genitive}, []string{"tseyä"}},
+		{"taron", ConjugationSpec{Infixes: []string{"ol"}}, []string{"tolaron"}},
+		{"taron", ConjugationSpec{Infixes: []string{"ei", "ol"}}, []string{"tolareion"}},
+		{"taron", ConjugationSpec{Infixes: []string{"eyk", "äp", "ìy"}}, []string{"täpeykìyaron"}},
+		{"taron", ConjugationSpec{Infixes: []string{"ep"}}, []string{"täparon"}},
+		{"taron", ConjugationSpec{Prefixes: []string{"a"}, Infixes: []string{"us"}}, []string{"atusaron"}},
+		{"taron", ConjugationSpec{Prefixes: []string{"tì"}, Infixes: []string{"us"}, Case: Agentive}, []string{"tìtusaronìl"}},
+		{"taron", ConjugationSpec{Prefixes: []string{"tsuk"}}, []string{"tsuktaron"}},
+		{"taron", ConjugationSpec{Prefixes: []string{"ay"}, Suffixes: []string{"yu"}}, []string{"aysaronyu", "saronyu"}},
+		{"zenke", ConjugationSpec{Infixes: []string{"ats"}}, []string{"zenatseke"}},
+		{"lor", ConjugationSpec{Prefixes: []string{"a"}}, []string{"alor"}},
+		{"lor", ConjugationSpec{Suffixes: []string{"a"}}, []string{"lora"}},
+		{"lor", ConjugationSpec{Prefixes: []string{"nì"}}, []string{"nìlor"}},
+	}
+	for _, tt := range tests {
+		got, err := Conjugate(words[tt.navi], tt.spec)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Conjugate(%s, %+v) = %v, %v, want %v", tt.navi, tt.spec, got, err, tt.want)
+		}
+	}
+}
+
+func TestConjugateInvalid(t *testing.T) {
+	words := conjugateTestWords()
+
+	tests := []struct {
+		navi string
+		spec ConjugationSpec
+	}{
+		{"tute", ConjugationSpec{Prefixes: []string{"fì", "tsa"}}},
+		{"tute", ConjugationSpec{Prefixes: []string{"xyz"}}},
+		{"tute", ConjugationSpec{Prefixes: []string{"tsuk"}}},
+		{"tute", ConjugationSpec{Infixes: []string{"ol"}}},
+		{"tute", ConjugationSpec{Case: Agentive, Adposition: "mì"}},
+		{"tute", ConjugationSpec{Adposition: "l"}},
+		{"taron", ConjugationSpec{Infixes: []string{"us", "ei"}}},
+		{"taron", ConjugationSpec{Infixes: []string{"awn", "äp"}}},
+		{"taron", ConjugationSpec{Infixes: []string{"ol", "er"}}},
+		{"taron", ConjugationSpec{Prefixes: []string{"tì"}, Infixes: []string{"ol"}}},
+		{"taron", ConjugationSpec{Prefixes: []string{"a"}}},
+		{"taron", ConjugationSpec{Prefixes: []string{"tsuk"}, Infixes: []string{"ol"}}},
+		{"taron", ConjugationSpec{Case: Agentive}},
+		{"lor", ConjugationSpec{Case: Agentive}},
+		{"lor", ConjugationSpec{Prefixes: []string{"a"}, Suffixes: []string{"a"}}},
+		{"lor", ConjugationSpec{Prefixes: []string{"nì"}, Suffixes: []string{"sì"}}},
+		// see productiveCompounds
+		{"fìtseng", ConjugationSpec{Prefixes: []string{"fì"}}},
+		{"tsaw", ConjugationSpec{Suffixes: []string{"tsyìp"}}},
+		// see ListAffixes
+		{"lor", ConjugationSpec{Prefixes: []string{"ke"}}},
+		{"taron", ConjugationSpec{Prefixes: []string{"sä"}}},
+	}
+	for _, tt := range tests {
+		if got, err := Conjugate(words[tt.navi], tt.spec); !errors.Is(err, InvalidConjugation) {
+			t.Errorf("Conjugate(%s, %+v) = %v, %v, want InvalidConjugation", tt.navi, tt.spec, got, err)
+		}
+	}
+}
+
+// Every form Conjugate makes is found again by the deconjugator
+func TestConjugateDeconjugate(t *testing.T) {
+	words := conjugateTestWords()
+	var all []Word
+	for _, word := range words {
+		all = append(all, word)
+	}
+	d := NewDictionary(NewMemorySource(all))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	specs := []ConjugationSpec{
+		{Case: Agentive}, {Case: Patientive}, {Case: Dative}, {Case: Genitive}, {Case: Topical},
+		{Prefixes: []string{"ay"}}, {Prefixes: []string{"fì", "ay"}, Case: Dative}, {Prefixes: []string{"pe"}},
+		{Suffixes: []string{"tsyìp"}, Case: Topical}, {Adposition: "kip"},
+		{Infixes: []string{"ol"}}, {Infixes: []string{"ìy", "ei"}}, {Prefixes: []string{"a"}, Infixes: []string{"us"}},
+		{Prefixes: []string{"a"}}, {Suffixes: []string{"a"}},
+	}
+	for _, word := range words {
+		for _, spec := range specs {
+			forms, err := Conjugate(word, spec)
+			if err != nil {
+				continue
+			}
+			for _, form := range forms {
+				results, err := d.SearchFromNavi(form, true, true, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				found := false
+				for _, match := range results[0].Matches {
+					found = found || match.Word.ID == word.ID
+				}
+				if !found {
+					t.Errorf("Conjugate(%s, %+v) = %s, not found by SearchFromNavi()", word.Navi, spec, form)
+				}
+			}
+		}
+	}
+}
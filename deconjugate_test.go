@@ -0,0 +1,122 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+var deconjugateTestQueries = []string{
+	"aysutet", "tolareion", "fayeylanìl", "tìtusaronìl", "fnetutetsyìp", "zenatseke", "pesuteyä", "tsatsmukane",
+}
+
+func deconjugateTestDictionary(tb testing.TB) *Dictionary {
+	words := []Word{}
+	for _, word := range conjugateTestWords() {
+		words = append(words, word)
+	}
+	d := NewDictionary(NewMemorySource(words))
+	if err := d.Load(); err != nil {
+		tb.Fatal(err)
+	}
+	return d
+}
+
+func TestDeconjugateConcurrent(t *testing.T) {
+	d := deconjugateTestDictionary(t)
+
+	want := map[string][]ConjugationCandidate{}
+	for _, query := range deconjugateTestQueries {
+		want[query] = d.Deconjugate(query, false, true)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 20 {
+				query := deconjugateTestQueries[(i+j)%len(deconjugateTestQueries)]
+				if got := d.Deconjugate(query, false, true); !reflect.DeepEqual(got, want[query]) {
+					t.Errorf("Deconjugate(%s) in parallel = %d candidates, want %d", query, len(got), len(want[query]))
+					return
+				}
+				if _, err := d.SearchFromNavi(query, true, false, true); err != nil {
+					t.Errorf("SearchFromNavi(%s) in parallel error = %v", query, err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// "locked" lets one call run at a time, like the dictionary-wide lock before every call had its own state.
+// Compare it with "parallel" on more than one CPU, e.g. go test -bench Deconjugate -cpu 1,4,8
+func BenchmarkDeconjugate(b *testing.B) {
+	d := deconjugateTestDictionary(b)
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d.Deconjugate(deconjugateTestQueries[i%len(deconjugateTestQueries)], false, true)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				d.Deconjugate(deconjugateTestQueries[i%len(deconjugateTestQueries)], false, true)
+				i++
+			}
+		})
+	})
+	b.Run("locked", func(b *testing.B) {
+		var lock sync.Mutex
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				lock.Lock()
+				d.Deconjugate(deconjugateTestQueries[i%len(deconjugateTestQueries)], false, true)
+				lock.Unlock()
+				i++
+			}
+		})
+	})
+}
+
+// The i spellings of ìly, ìry and ìrm are loose like the one of ìy,
+// and a loose ap is the pre-first äp, so it goes with a second position infix.
+func TestDeconjugateLooseInfixes(t *testing.T) {
+	d := deconjugateTestDictionary(t)
+
+	tests := []struct {
+		query  string
+		strict bool
+		// the infixes taron is found with, nil if it isn't
+		want []string
+	}{
+		{"tìlyaron", true, []string{"ìly"}},
+		{"tilyaron", true, nil},               // before: [ily]
+		{"tilyaron", false, []string{"ìly"}},  // before: [ily]
+		{"tiryaron", true, nil},               // before: [iry]
+		{"tiryaron", false, []string{"ìry"}},  // before: [iry]
+		{"tirmaron", true, nil},               // before: [irm]
+		{"tirmaron", false, []string{"ìrm"}},  // before: [irm]
+		{"tiyevaron", true, []string{"iyev"}}, // iyev stays a variant
+		{"taparon", true, nil},
+		{"taparon", false, []string{"äp"}},
+		{"taparangon", false, []string{"äp", "äng"}}, // before: not found
+		{"täparängon", true, []string{"äp", "äng"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, candidate := range d.Deconjugate(tt.query, tt.strict, false) {
+			if candidate.Word == "taron" {
+				got = candidate.Infixes
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Deconjugate(%s, strict %v) finds taron with %v, want %v", tt.query, tt.strict, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,420 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. dictionary.go is home to the Dictionary struct.
+package fwew_lib
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Dictionary holds one version of the dictionary and every cache built from it.
+// More than one can live in the same program (e.g. a stable and a preview version).
+type Dictionary struct {
+	// where the words come from
+	source DictionarySource
+
+	// Lookups share the read lock.  Reloads build everything on the side
+	// and only take the write lock to swap the new caches in.
+	lock sync.RWMutex
+
+	// Only one reload at a time
+	reloadLock sync.Mutex
+
+	words       []Word
+	wordsCached bool
+
+	// every language the definitions are in, sorted, see LanguageCodes
+	languages []string
+
+	// Na'vi to natural language
+	hashLoose      map[string][]Word
+	hashStrict     map[string][]Word
+	hashStrictReef map[string][]Word
+	// by the IPA without stress and dots (ipaKey) and by what it sounds like (soundsLikeKey), for SearchIPA
+	hashIPA        map[string][]Word
+	hashSoundsLike map[string][]Word
+	hashCached     bool
+
+	// Natural languages to Na'vi
+	hash2            MetaDict
+	hash2Parenthesis MetaDict
+	// where the words are in every definition, for phrases
+	hash2Positions positionDict
+	// the same as hash2Parenthesis, with every word normalized, see NatlangOptions
+	hash2Normalized MetaDict
+	hash2Cached     bool
+
+	homonyms string
+	oddballs string
+	multiIPA string
+
+	// words with nkx, so reef ng can find them
+	nkx    []string
+	nkxSub map[string]string
+
+	// words known to start multiword words
+	multiwords      map[string][][]string
+	multiwordsLoose map[string][][]string
+	multiwordsReef  map[string][][]string
+
+	// built by Complete when first needed, it has its own lock for that
+	completionLock sync.Mutex
+	completions    *completionIndex
+}
+
+// NewDictionary creates an empty dictionary reading its words from source.
+// Nothing is read until Load() or one of the Cache functions is called.
+func NewDictionary(source DictionarySource) *Dictionary {
+	return &Dictionary{
+		source:          source,
+		nkx:             []string{},
+		nkxSub:          map[string]string{},
+		multiwords:      map[string][][]string{},
+		multiwordsLoose: map[string][][]string{},
+		multiwordsReef:  map[string][][]string{},
+	}
+}
+
+// The dictionary used by all the package-level functions
+var defaultDictionary = NewDictionary(NewFileSource(""))
+
+// Source returns where the dictionary reads its words from
+func (d *Dictionary) Source() DictionarySource {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.source
+}
+
+// SetSource changes where the words come from and drops everything cached.
+// Call Load() afterwards to read the new source.
+func (d *Dictionary) SetSource(source DictionarySource) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.source = source
+	d.uncacheDict()
+	d.uncacheHashDict()
+	d.uncacheHashDict2()
+}
+
+// Load caches the whole dictionary, both hash caches and the multiword words.
+// The source is only read once.  After this, the dictionary is ready to translate.
+//
+// Everything is built on the side while lookups keep using the old caches.
+// If reading the source fails, the old caches stay in use and the error is returned.
+func (d *Dictionary) Load() error {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+	return d.reload()
+}
+
+// reload does the work of Load, d.reloadLock must be held.
+// Every word is also handed to each of the extra functions.
+func (d *Dictionary) reload(extra ...func(word Word)) error {
+	fresh := NewDictionary(d.Source())
+	err := fresh.load(extra...)
+	if err != nil {
+		return err
+	}
+
+	d.swap(fresh)
+	return nil
+}
+
+// load does the work of Load on a dictionary nobody else can see yet.
+// Every word is also handed to each of the extra functions.
+func (d *Dictionary) load(extra ...func(word Word)) error {
+	d.resetHash()
+	d.resetHash2()
+
+	tempHoms := []string{}
+	err := d.source.Run(func(word Word) error {
+		d.words = append(d.words, word)
+		d.addLanguages(word)
+		d.hashWord(word, &tempHoms)
+		d.hash2Word(word)
+		for _, f := range extra {
+			f(word)
+		}
+		return nil
+	})
+	if err != nil {
+		d.uncacheDict()
+		d.uncacheHashDict()
+		d.uncacheHashDict2()
+		return err
+	}
+
+	sortByID(d.words)
+	d.wordsCached = true
+	d.finishHash(tempHoms)
+	d.hash2Cached = true
+	d.cacheMultiwords(d.words)
+
+	return nil
+}
+
+// swap puts the caches of fresh in place of the current ones.
+// Lookups running right now finish on the old caches.
+func (d *Dictionary) swap(fresh *Dictionary) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.takeWords(fresh)
+	d.takeHash(fresh)
+	d.takeHash2(fresh)
+
+	d.multiwords = fresh.multiwords
+	d.multiwordsLoose = fresh.multiwordsLoose
+	d.multiwordsReef = fresh.multiwordsReef
+}
+
+// The take functions put one cache of fresh in place, d.lock must be held.
+// CacheDict, CacheDictHash and CacheDictHash2 build only theirs.
+func (d *Dictionary) takeWords(fresh *Dictionary) {
+	d.words = fresh.words
+	d.wordsCached = fresh.wordsCached
+	d.languages = fresh.languages
+	d.completions = nil
+}
+
+func (d *Dictionary) takeHash(fresh *Dictionary) {
+	d.hashLoose = fresh.hashLoose
+	d.hashStrict = fresh.hashStrict
+	d.hashStrictReef = fresh.hashStrictReef
+	d.hashIPA = fresh.hashIPA
+	d.hashSoundsLike = fresh.hashSoundsLike
+	d.hashCached = fresh.hashCached
+	d.languages = fresh.languages
+
+	d.homonyms = fresh.homonyms
+	d.oddballs = fresh.oddballs
+	d.multiIPA = fresh.multiIPA
+
+	d.nkx = fresh.nkx
+	d.nkxSub = fresh.nkxSub
+}
+
+func (d *Dictionary) takeHash2(fresh *Dictionary) {
+	d.hash2 = fresh.hash2
+	d.hash2Parenthesis = fresh.hash2Parenthesis
+	d.hash2Positions = fresh.hash2Positions
+	d.hash2Normalized = fresh.hash2Normalized
+	d.hash2Cached = fresh.hash2Cached
+	d.languages = fresh.languages
+	d.completions = nil
+}
+
+// Everything below works on the default dictionary
+
+func SetDictionarySource(source DictionarySource) {
+	defaultDictionary.SetSource(source)
+}
+
+// LoadWithSnapshot loads the default dictionary from the snapshot at path if it is up-to-date,
+// otherwise it is loaded from its source and a new snapshot is saved.
+// The name generator is set up afterwards, like StartEverything does.
+func LoadWithSnapshot(path string) error {
+	err := defaultDictionary.LoadWithSnapshot(path)
+	if err != nil {
+		return err
+	}
+	PhonemeDistros()
+	return nil
+}
+
+func WatchDictionary(ctx context.Context, interval time.Duration, report func(event ReloadEvent)) error {
+	return defaultDictionary.Watch(ctx, interval, report)
+}
+
+func SaveSnapshot(path string) error {
+	return defaultDictionary.SaveSnapshot(path)
+}
+
+func UncacheDict() {
+	defaultDictionary.UncacheDict()
+}
+
+func CacheDict() error {
+	return defaultDictionary.CacheDict()
+}
+
+func CacheDictHash() error {
+	return defaultDictionary.CacheDictHash()
+}
+
+func CacheDictHash2() error {
+	return defaultDictionary.CacheDictHash2()
+}
+
+// CacheDictHashOrig is CacheDictHash reading from the fwedit database (see NewMySQLSourceFromEnv) or the dictionary file.
+//
+// Deprecated: use SetDictionarySource and CacheDictHash, or Load.
+func CacheDictHashOrig(mysql bool) error {
+	return defaultDictionary.cacheDictHashFrom(legacySource(mysql))
+}
+
+// CacheDictHash2Orig is CacheDictHash2 reading from the fwedit database (see NewMySQLSourceFromEnv) or the dictionary file.
+//
+// Deprecated: use SetDictionarySource and CacheDictHash2, or Load.
+func CacheDictHash2Orig(mysql bool) error {
+	return defaultDictionary.cacheDictHash2From(legacySource(mysql))
+}
+
+// The sources the Orig functions chose between
+func legacySource(mysql bool) DictionarySource {
+	if mysql {
+		return NewMySQLSourceFromEnv()
+	}
+	return NewFileSource("")
+}
+
+func UncacheHashDict() {
+	defaultDictionary.UncacheHashDict()
+}
+
+func UncacheHashDict2() {
+	defaultDictionary.UncacheHashDict2()
+}
+
+func RunOnDict(f func(word Word) error) error {
+	return defaultDictionary.RunOnDict(f)
+}
+
+func Lint(options LintOptions) ([]Problem, error) {
+	return defaultDictionary.Lint(options)
+}
+
+func GetFullDict() (allWords []Word, err error) {
+	return defaultDictionary.GetFullDict()
+}
+
+func GetDictSizeSimple() (count int) {
+	return defaultDictionary.GetDictSizeSimple()
+}
+
+func GetDictSize(lang string) (count string, err error) {
+	return defaultDictionary.GetDictSize(lang)
+}
+
+func UpdateDict() error {
+	return defaultDictionary.UpdateDict()
+}
+
+func UpdateDictWith(ctx context.Context, downloader *Downloader) (DictionaryDiff, error) {
+	return defaultDictionary.UpdateDictWith(ctx, downloader)
+}
+
+func SearchFromNavi(searchNaviWords string, checkFixes bool, strict bool, allowReef bool) ([]SearchResult, error) {
+	return defaultDictionary.SearchFromNavi(searchNaviWords, checkFixes, strict, allowReef)
+}
+
+func TranslateFromNaviHash(searchNaviWords string, checkFixes bool, strict bool, allowReef bool) (results [][]Word, err error) {
+	return defaultDictionary.TranslateFromNaviHash(searchNaviWords, checkFixes, strict, allowReef)
+}
+
+func Suggest(query string, limit int) []Suggestion {
+	return defaultDictionary.Suggest(query, limit)
+}
+
+func SearchIPA(query string, soundsLike bool) SearchResult {
+	return defaultDictionary.SearchIPA(query, soundsLike)
+}
+
+func Complete(prefix string, lang string, limit int) []Completion {
+	return defaultDictionary.Complete(prefix, lang, limit)
+}
+
+func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []string, checkFixes bool, strict bool, allowReef bool) (steps int, results [][]Word, err error) {
+	return defaultDictionary.TranslateFromNaviHashHelper(dict, start, allWords, checkFixes, strict, allowReef)
+}
+
+func IsVerb(dict *map[string][]Word, input string, comparator string, strict bool, allowReef bool) (result bool, affixes Word) {
+	return defaultDictionary.IsVerb(dict, input, comparator, strict, allowReef)
+}
+
+func SearchNatlangWord(wordmap map[string][]string, searchWord string) (results []Word) {
+	return defaultDictionary.SearchNatlangWord(wordmap, searchWord)
+}
+
+func SearchToNavi(searchWord string, langCode string) []SearchResult {
+	return defaultDictionary.SearchToNavi(searchWord, langCode)
+}
+
+func SearchToNaviWith(searchWord string, langCode string, options NatlangOptions) []SearchResult {
+	return defaultDictionary.SearchToNaviWith(searchWord, langCode, options)
+}
+
+func TranslateToNaviHash(searchWord string, langCode string) (results [][]Word) {
+	return defaultDictionary.TranslateToNaviHash(searchWord, langCode)
+}
+
+func SearchDefinitions(query string, langCode string) SearchResult {
+	return defaultDictionary.SearchDefinitions(query, langCode)
+}
+
+func TranslateToNaviHashHelper(dictionary *MetaDict, searchWord string, langCode string) (results []Word) {
+	return defaultDictionary.TranslateToNaviHashHelper(dictionary, searchWord, langCode)
+}
+
+func SearchBidirectional(searchNaviWords string, checkFixes bool, langCode string, allowReef bool) ([]SearchResult, error) {
+	return defaultDictionary.SearchBidirectional(searchNaviWords, checkFixes, langCode, allowReef)
+}
+
+func BidirectionalSearch(searchNaviWords string, checkFixes bool, langCode string, allowReef bool) (results [][]Word, err error) {
+	return defaultDictionary.BidirectionalSearch(searchNaviWords, checkFixes, langCode, allowReef)
+}
+
+func Random(amount int, args []string, checkDigraphs uint8) (results []Word, err error) {
+	return defaultDictionary.Random(amount, args, checkDigraphs)
+}
+
+func List(args []string, checkDigraphs uint8) (results []Word, err error) {
+	return defaultDictionary.List(args, checkDigraphs)
+}
+
+func ListHelp(lang string) (count string, err error) {
+	return defaultDictionary.ListHelp(lang)
+}
+
+func GetMultiwordWords() map[string][][]string {
+	return defaultDictionary.GetMultiwordWords()
+}
+
+func GetHomonyms() (results [][]Word, err error) {
+	return defaultDictionary.GetHomonyms()
+}
+
+func GetOddballs() (results [][]Word, err error) {
+	return defaultDictionary.GetOddballs()
+}
+
+func GetMultiIPA() (results [][]Word, err error) {
+	return defaultDictionary.GetMultiIPA()
+}
+
+func Deconjugate(input string, strict bool, allowReef bool) []ConjugationCandidate {
+	return defaultDictionary.Deconjugate(input, strict, allowReef)
+}
+
+func ExplainDeconjugation(query string, strict bool, allowReef bool) []Explanation {
+	return defaultDictionary.ExplainDeconjugation(query, strict, allowReef)
+}
+
+func TestDeconjugations(dict *map[string][]Word, searchNaviWord string, strict bool, allowReef bool, umlaut bool) (results []Word) {
+	return defaultDictionary.TestDeconjugations(dict, searchNaviWord, strict, allowReef, umlaut)
+}
@@ -0,0 +1,538 @@
+package fwew_lib
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testDictHeader = "id\tnavi\tipa\tinfixes\tpartOfSpeech\tsource\tstressed\tsyllables\tinfixDots\t" +
+	"de\ten\tes\tet\tfr\thu\tit\tko\tnl\tpl\tpt\tru\tsv\ttr\tuk"
+
+// write a tiny dictionary file and return its path
+func writeTestDict(t testing.TB, rows ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), dictFileName)
+	content := testDictHeader + "\n" + strings.Join(rows, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// one dictionary row with the same definition in every language
+func testDictRow(id, navi, ipa, infixes, pos, stressed, syllables, infixDots, definition string) string {
+	fields := []string{id, navi, ipa, infixes, pos, "test", stressed, syllables, infixDots}
+	for range 15 {
+		fields = append(fields, definition)
+	}
+	return strings.Join(fields, "\t")
+}
+
+// parse rows the same way the dictionary file is parsed
+func testWords(rows ...string) []Word {
+	pos := readDictPos(strings.Split(testDictHeader, "\t"))
+	words := make([]Word, 0, len(rows))
+	for _, row := range rows {
+		words = append(words, newWord(strings.Split(row, "\t"), pos))
+	}
+	return words
+}
+
+func TestDictionaryInstances(t *testing.T) {
+	stable := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	)))
+	preview := NewDictionary(NewFileSource(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "people person"),
+		testDictRow("3", "tsmukan", "ˈt͡smu.kan", "NULL", "n.", "1", "tsmu-kan", "NULL", "brother"),
+	)))
+
+	if err := stable.Load(); err != nil {
+		t.Fatalf("Load() stable error = %v", err)
+	}
+	if err := preview.Load(); err != nil {
+		t.Fatalf("Load() preview error = %v", err)
+	}
+
+	if got := stable.GetDictSizeSimple(); got != 2 {
+		t.Errorf("stable GetDictSizeSimple() = %d, want 2", got)
+	}
+
+	got, err := stable.TranslateFromNaviHash("tuteti", true, false, false)
+	if err != nil || len(got) != 1 || len(got[0]) != 2 || got[0][1].Definitions["en"] != "person" {
+		t.Errorf("stable TranslateFromNaviHash() = %v, %v", got, err)
+	} else if len(got[0][1].Affixes.Suffix) != 1 || got[0][1].Affixes.Suffix[0] != "ti" {
+		t.Errorf("stable TranslateFromNaviHash() affixes = %v", got[0][1].Affixes)
+	}
+
+	got, err = preview.TranslateFromNaviHash("tute", true, false, false)
+	if err != nil || len(got) != 1 || len(got[0]) != 2 || got[0][1].Definitions["en"] != "people person" {
+		t.Errorf("preview TranslateFromNaviHash() = %v, %v", got, err)
+	}
+
+	// kaltxì only exists in the stable version
+	if got := preview.TranslateToNaviHash("hello", "en"); len(got) != 1 || len(got[0]) != 1 {
+		t.Errorf("preview TranslateToNaviHash() = %v", got)
+	}
+	if got := stable.TranslateToNaviHash("hello", "en"); len(got) != 1 || len(got[0]) != 2 || got[0][1].Navi != "kaltxì" {
+		t.Errorf("stable TranslateToNaviHash() = %v", got)
+	}
+
+	words, err := preview.List([]string{"word", "starts", "ts"}, 1)
+	if err != nil || len(words) != 1 || words[0].Navi != "tsmukan" {
+		t.Errorf("preview List() = %v, %v", words, err)
+	}
+}
+
+func TestDictionarySources(t *testing.T) {
+	rows := []string{
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	}
+	sources := []DictionarySource{
+		NewFileSource(writeTestDict(t, rows...)),
+		NewMemorySource(testWords(rows...)),
+	}
+
+	for _, source := range sources {
+		t.Run(source.Name(), func(t *testing.T) {
+			var got []string
+			err := source.Run(func(word Word) error {
+				got = append(got, word.Navi)
+				return nil
+			})
+			if err != nil || len(got) != 2 || got[0] != "tute" || got[1] != "kaltxì" {
+				t.Errorf("Run() = %v, %v", got, err)
+			}
+
+			// Run stops at the first error
+			calls := 0
+			err = source.Run(func(word Word) error {
+				calls++
+				return NoResults
+			})
+			if err != NoResults || calls != 1 {
+				t.Errorf("Run() with error = %v after %d calls", err, calls)
+			}
+		})
+	}
+
+	if err := NewFileSource(filepath.Join(t.TempDir(), "missing.txt")).Run(func(word Word) error { return nil }); err == nil {
+		t.Error("Run() on a missing file should fail")
+	}
+}
+
+func TestDictionarySetSource(t *testing.T) {
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	d.SetSource(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatalf("Load() after SetSource() error = %v", err)
+	}
+	if got := d.GetDictSizeSimple(); got != 2 {
+		t.Errorf("GetDictSizeSimple() = %d, want 2", got)
+	}
+	if got := d.TranslateToNaviHash("hello", "en"); len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("TranslateToNaviHash() = %v", got)
+	}
+}
+
+func TestDictionaryLoadSinglePass(t *testing.T) {
+	path := writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "female person"),
+		testDictRow("3", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello (greeting)"),
+		testDictRow("4", "tsun si", "ˈt͡sun ˈsi", "NULL", "vin.", "1", "tsun si", "NULL", "be able"),
+		testDictRow("5", "fìtxan", "fɪ.ˈtʼan or ˈfɪ.tʼan", "NULL", "adv.", "2", "fì-txan", "NULL", "so (much)"),
+	)
+
+	separate := NewDictionary(NewFileSource(path))
+	if err := separate.CacheDict(); err != nil {
+		t.Fatal(err)
+	}
+	if err := separate.CacheDictHash(); err != nil {
+		t.Fatal(err)
+	}
+	if err := separate.CacheDictHash2(); err != nil {
+		t.Fatal(err)
+	}
+	separate.cacheMultiwords(separate.words)
+
+	// count how often the source is read
+	reads := 0
+	source := NewFileSource(path)
+	once := NewDictionary(countingSource{source, &reads})
+	if err := once.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if reads != 1 {
+		t.Errorf("Load() read the source %d times, want 1", reads)
+	}
+
+	if !reflect.DeepEqual(once.words, separate.words) {
+		t.Errorf("words differ")
+	}
+	if !reflect.DeepEqual(once.hashLoose, separate.hashLoose) ||
+		!reflect.DeepEqual(once.hashStrict, separate.hashStrict) ||
+		!reflect.DeepEqual(once.hashStrictReef, separate.hashStrictReef) {
+		t.Errorf("Na'vi hashes differ")
+	}
+	if !reflect.DeepEqual(once.hash2, separate.hash2) || !reflect.DeepEqual(once.hash2Parenthesis, separate.hash2Parenthesis) {
+		t.Errorf("natural language hashes differ")
+	}
+	if once.homonyms != separate.homonyms || once.oddballs != separate.oddballs || once.multiIPA != separate.multiIPA {
+		t.Errorf("homonyms, oddballs or multiIPA differ: %q %q %q", once.homonyms, once.oddballs, once.multiIPA)
+	}
+	if !reflect.DeepEqual(once.multiwords, separate.multiwords) {
+		t.Errorf("multiwords = %v, want %v", once.multiwords, separate.multiwords)
+	}
+
+	// loading again must not pile anything up
+	if err := once.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if once.homonyms != separate.homonyms || once.oddballs != separate.oddballs || len(once.words) != 5 {
+		t.Errorf("second Load() = %q %q %d", once.homonyms, once.oddballs, len(once.words))
+	}
+}
+
+type countingSource struct {
+	DictionarySource
+	reads *int
+}
+
+func (s countingSource) Run(f func(word Word) error) error {
+	*s.reads++
+	return s.DictionarySource.Run(f)
+}
+
+// a dictionary file with a few thousand made up words
+func writeBenchmarkDict(b *testing.B) string {
+	b.Helper()
+	syllables := []string{"ta", "ke", "lu", "po", "mi", "na", "si"}
+	ipa := map[string]string{"ta": "ta", "ke": "kɛ", "lu": "lu", "po": "po", "mi": "mi", "na": "na", "si": "si"}
+
+	var rows []string
+	var build func(navi, pronunciation, breakdown string, left int)
+	build = func(navi, pronunciation, breakdown string, left int) {
+		if left == 0 {
+			id := strconv.Itoa(len(rows) + 1)
+			rows = append(rows, testDictRow(id, navi, "ˈ"+pronunciation, "NULL", "n.", "1", breakdown, "NULL", "thing"+id))
+			return
+		}
+		for _, a := range syllables {
+			if navi == "" {
+				build(a, ipa[a], a, left-1)
+			} else {
+				build(navi+a, pronunciation+"."+ipa[a], breakdown+"-"+a, left-1)
+			}
+		}
+	}
+	build("", "", "", 3)
+	build("", "", "", 4)
+
+	return writeTestDict(b, rows...)
+}
+
+// The MySQL source pays a round trip for connecting and for each of its two queries,
+// and the driver scans a row for every word and every definition of it.
+// The costs are those of a database server on the same network.
+type queryCostSource struct {
+	DictionarySource
+	roundTrip time.Duration
+	rowCost   time.Duration
+}
+
+func (s queryCostSource) Run(f func(word Word) error) error {
+	time.Sleep(3 * s.roundTrip)
+	rows := 0
+	return s.DictionarySource.Run(func(word Word) error {
+		// the driver reads the rows in batches
+		rows++
+		if rows%100 == 0 {
+			time.Sleep(100 * s.rowCost)
+		}
+		return f(word)
+	})
+}
+
+type benchmarkSource struct {
+	name   string
+	source DictionarySource
+}
+
+func benchmarkSources(b *testing.B) []benchmarkSource {
+	file := NewFileSource(writeBenchmarkDict(b))
+	return []benchmarkSource{
+		{"File", file},
+		{"MySQL", queryCostSource{file, time.Millisecond, 10 * time.Microsecond}},
+	}
+}
+
+// What StartEverything used to do: every cache and the phoneme counting read the words on their own
+func BenchmarkLoadSeparate(b *testing.B) {
+	for _, bs := range benchmarkSources(b) {
+		b.Run(bs.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d := NewDictionary(bs.source)
+				if err := errors.Join(d.CacheDict(), d.CacheDictHash(), d.CacheDictHash2()); err != nil {
+					b.Fatal(err)
+				}
+
+				// PhonemeDistros
+				phonoLock.Lock()
+				words, err := d.List([]string{}, 0)
+				if err != nil {
+					b.Fatal(err)
+				}
+				d.lock.Lock()
+				d.cacheMultiwords(words)
+				d.lock.Unlock()
+				resetPhonemeDistros()
+				for _, word := range words {
+					countPhonemes(word)
+				}
+				finishPhonemeDistros()
+				phonoLock.Unlock()
+			}
+		})
+	}
+}
+
+// What StartEverything does: one read for the caches and the phoneme counting
+func BenchmarkLoad(b *testing.B) {
+	for _, bs := range benchmarkSources(b) {
+		b.Run(bs.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d := NewDictionary(bs.source)
+				phonoLock.Lock()
+				resetPhonemeDistros()
+				d.reloadLock.Lock()
+				err := d.reload(countPhonemes)
+				d.reloadLock.Unlock()
+				if err != nil {
+					b.Fatal(err)
+				}
+				finishPhonemeDistros()
+				phonoLock.Unlock()
+			}
+		})
+	}
+}
+
+// A source whose words can be changed, or made to fail, while it is in use
+type changingSource struct {
+	lock  sync.Mutex
+	words []Word
+	err   error
+}
+
+func (s *changingSource) Name() string {
+	return "Changing"
+}
+
+func (s *changingSource) Run(f func(word Word) error) error {
+	s.lock.Lock()
+	words, err := s.words, s.err
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	return NewMemorySource(words).Run(f)
+}
+
+func (s *changingSource) set(words []Word, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.words, s.err = words, err
+}
+
+func TestDictionaryHotReload(t *testing.T) {
+	small := testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	)
+	big := testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "female person"),
+		testDictRow("3", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	)
+	source := &changingSource{words: small}
+	d := NewDictionary(source)
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// lookups never see a half built dictionary while it is reloaded
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				got, err := d.TranslateFromNaviHash("tuteti", true, false, false)
+				if err != nil || len(got) != 1 || len(got[0]) < 2 {
+					t.Errorf("TranslateFromNaviHash() during reload = %v, %v", got, err)
+					return
+				}
+				if got := d.TranslateToNaviHash("person", "en"); len(got) != 1 || len(got[0]) < 2 {
+					t.Errorf("TranslateToNaviHash() during reload = %v", got)
+					return
+				}
+				if words, err := d.List([]string{"word", "starts", "tu"}, 1); err != nil || len(words) == 0 {
+					t.Errorf("List() during reload = %v, %v", words, err)
+					return
+				}
+				d.GetHomonyms()
+				d.GetDictSizeSimple()
+			}
+		}()
+	}
+
+	for i := range 20 {
+		if i%2 == 0 {
+			source.set(big, nil)
+		} else {
+			source.set(small, nil)
+		}
+		if err := d.Load(); err != nil {
+			t.Errorf("Load() error = %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+
+	// a failed reload keeps the old dictionary
+	source.set(big, nil)
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	source.set(nil, DictionaryNotFound)
+	if err := d.Load(); err != DictionaryNotFound {
+		t.Errorf("Load() of a broken source error = %v", err)
+	}
+	if got := d.GetDictSizeSimple(); got != 3 {
+		t.Errorf("GetDictSizeSimple() after a failed reload = %d, want 3", got)
+	}
+	if got, err := d.GetHomonyms(); err != nil || len(got) != 1 || len(got[0]) != 3 {
+		t.Errorf("GetHomonyms() after a failed reload = %v, %v", got, err)
+	}
+	if got := d.TranslateToNaviHash("hello", "en"); len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("TranslateToNaviHash() after a failed reload = %v", got)
+	}
+}
+
+// A source that waits in Run until it is released, once block is set
+type blockingSource struct {
+	DictionarySource
+	block   bool
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSource) Run(f func(word Word) error) error {
+	if s.block {
+		s.entered <- struct{}{}
+		<-s.release
+	}
+	return s.DictionarySource.Run(f)
+}
+
+// The Cache functions read on the side like Load, lookups don't wait for the source
+func TestDictionaryCacheWithoutBlocking(t *testing.T) {
+	source := &blockingSource{
+		DictionarySource: NewMemorySource(testWords(
+			testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		)),
+		entered: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	d := NewDictionary(source)
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	source.block = true
+
+	caches := []struct {
+		name  string
+		cache func() error
+	}{
+		{"CacheDict", d.CacheDict},
+		{"CacheDictHash", func() error { d.UncacheHashDict(); return d.CacheDictHash() }},
+		{"CacheDictHash2", func() error { d.UncacheHashDict2(); return d.CacheDictHash2() }},
+	}
+	for _, tt := range caches {
+		done := make(chan error)
+		go func() { done <- tt.cache() }()
+		<-source.entered
+
+		looked := make(chan int)
+		go func() { looked <- d.GetDictSizeSimple() }()
+		select {
+		case got := <-looked:
+			if got != 1 {
+				t.Errorf("GetDictSizeSimple() during %s = %d, want 1", tt.name, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("GetDictSizeSimple() waited for %s", tt.name)
+		}
+
+		source.release <- struct{}{}
+		if err := <-done; err != nil {
+			t.Errorf("%s() error = %v", tt.name, err)
+		}
+	}
+	if got := d.TranslateToNaviHash("person", "en"); len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("TranslateToNaviHash() after the Cache functions = %v", got)
+	}
+}
+
+// The old entry points still fill the default dictionary
+func TestCacheDictHashOrig(t *testing.T) {
+	if FindDictionaryFile() != "" {
+		t.Skip("a dictionary file is found, the fallback is never used")
+	}
+	old := fallbackDictionary
+	t.Cleanup(func() {
+		SetFallbackDictionary(old)
+		UncacheHashDict()
+		UncacheHashDict2()
+	})
+	data, _ := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	))
+	SetFallbackDictionary(data)
+
+	UncacheHashDict()
+	UncacheHashDict2()
+	if err := errors.Join(CacheDictHashOrig(false), CacheDictHash2Orig(false)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := TranslateFromNaviHash("kaltxì", false, false, false); err != nil || len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("TranslateFromNaviHash() = %v, %v", got, err)
+	}
+	if got := TranslateToNaviHash("hello", "en"); len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("TranslateToNaviHash() = %v", got)
+	}
+}
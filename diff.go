@@ -0,0 +1,151 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. diff.go compares two versions of the dictionary.
+package fwew_lib
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+)
+
+// FieldChange is one field of an entry that is different in the new dictionary
+type FieldChange struct {
+	// Name of the Word field, e.g. "Navi" or "IPA", or the language code of a definition, e.g. "en"
+	Field string
+	Old   string
+	New   string
+}
+
+// WordChange is an entry that is in both dictionaries, but not the same
+type WordChange struct {
+	ID     string
+	Old    Word
+	New    Word
+	Fields []FieldChange
+}
+
+// DictionaryDiff is everything that changed between two dictionaries.
+// All lists are sorted by ID.
+type DictionaryDiff struct {
+	Added   []Word
+	Removed []Word
+	Changed []WordChange
+}
+
+// Empty is true if both dictionaries are the same
+func (diff DictionaryDiff) Empty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// A string field of Word
+type wordField struct {
+	name string
+	get  func(w *Word) string
+}
+
+// The fields compared by DiffWords, besides the definitions
+var diffFields = []wordField{
+	{"Navi", func(w *Word) string { return w.Navi }},
+	{"IPA", func(w *Word) string { return w.IPA }},
+	{"PartOfSpeech", func(w *Word) string { return w.PartOfSpeech }},
+	{"Syllables", func(w *Word) string { return w.Syllables }},
+	{"Stressed", func(w *Word) string { return w.Stressed }},
+}
+
+// DiffDictionaries reads both sources and compares their entries by ID.
+func DiffDictionaries(old, new DictionarySource) (diff DictionaryDiff, err error) {
+	oldWords, err := sourceWords(old)
+	if err != nil {
+		return
+	}
+	newWords, err := sourceWords(new)
+	if err != nil {
+		return
+	}
+	return DiffWords(oldWords, newWords), nil
+}
+
+func sourceWords(source DictionarySource) (words []Word, err error) {
+	err = source.Run(func(word Word) error {
+		words = append(words, word)
+		return nil
+	})
+	return
+}
+
+// DiffWords compares two lists of entries by ID.
+// Only Navi, IPA, PartOfSpeech, Syllables, Stressed and the definitions are compared.
+func DiffWords(old, new []Word) (diff DictionaryDiff) {
+	oldByID := make(map[string]Word, len(old))
+	for _, word := range old {
+		oldByID[word.ID] = word
+	}
+
+	seen := make(map[string]bool, len(new))
+	for _, newWord := range new {
+		seen[newWord.ID] = true
+
+		oldWord, ok := oldByID[newWord.ID]
+		if !ok {
+			diff.Added = append(diff.Added, newWord)
+			continue
+		}
+
+		var fields []FieldChange
+		for _, field := range diffFields {
+			before, after := field.get(&oldWord), field.get(&newWord)
+			if before != after {
+				fields = append(fields, FieldChange{Field: field.name, Old: before, New: after})
+			}
+		}
+		for _, code := range definitionCodes(oldWord.Definitions, newWord.Definitions) {
+			before, after := oldWord.Definitions[code], newWord.Definitions[code]
+			if before != after {
+				fields = append(fields, FieldChange{Field: code, Old: before, New: after})
+			}
+		}
+		if len(fields) > 0 {
+			diff.Changed = append(diff.Changed, WordChange{ID: newWord.ID, Old: oldWord, New: newWord, Fields: fields})
+		}
+	}
+
+	for _, oldWord := range old {
+		if !seen[oldWord.ID] {
+			diff.Removed = append(diff.Removed, oldWord)
+		}
+	}
+
+	slices.SortFunc(diff.Added, func(a, b Word) int { return compareIDs(a.ID, b.ID) })
+	slices.SortFunc(diff.Removed, func(a, b Word) int { return compareIDs(a.ID, b.ID) })
+	slices.SortFunc(diff.Changed, func(a, b WordChange) int { return compareIDs(a.ID, b.ID) })
+
+	return
+}
+
+// IDs are numbers, but sort anything else after them
+func compareIDs(a, b string) int {
+	a1, errA := strconv.Atoi(a)
+	b1, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(a1, b1)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return cmp.Compare(a, b)
+}
@@ -0,0 +1,75 @@
+package fwew_lib
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiffDictionaries(t *testing.T) {
+	old := testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+		testDictRow("10", "tsmukan", "ˈt͡smu.kan", "NULL", "n.", "1", "tsmu-kan", "NULL", "brother"),
+		testDictRow("3", "skxawng", "ˈskʼaʊŋ", "NULL", "n.", "1", "skxawng", "NULL", "moron"),
+	)
+	changed := testWords(
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hi"),
+		testDictRow("3", "skxawng", "ˈskʼaʊŋ", "NULL", "n.", "1", "skxawng", "NULL", "moron"),
+		testDictRow("10", "tsmukan", "ˈt͡smu.kan", "NULL", "n.", "2", "tsmu-kan", "NULL", "brother"),
+		testDictRow("12", "tsmuke", "ˈt͡smu.kɛ", "NULL", "n.", "1", "tsmu-ke", "NULL", "sister"),
+		testDictRow("4", "eylan", "ˈɛj.lan", "NULL", "n.", "1", "ey-lan", "NULL", "friend"),
+	)
+
+	diff, err := DiffDictionaries(NewMemorySource(old), NewMemorySource(changed))
+	if err != nil {
+		t.Fatalf("DiffDictionaries() error = %v", err)
+	}
+
+	if ids := wordIDs(diff.Added); !reflect.DeepEqual(ids, []string{"4", "12"}) {
+		t.Errorf("Added = %v, want [4 12]", ids)
+	}
+	if ids := wordIDs(diff.Removed); !reflect.DeepEqual(ids, []string{"1"}) {
+		t.Errorf("Removed = %v, want [1]", ids)
+	}
+	if len(diff.Changed) != 2 || diff.Changed[0].ID != "2" || diff.Changed[1].ID != "10" {
+		t.Fatalf("Changed = %+v, want 2 and 10", diff.Changed)
+	}
+
+	// the definition is the same in every language
+	hi := diff.Changed[0]
+	if len(hi.Fields) != 15 || hi.Old.Definitions["en"] != "hello" || hi.New.Definitions["en"] != "hi" {
+		t.Errorf("Changed[0] = %+v", hi)
+	}
+	for _, field := range hi.Fields {
+		if field.Old != "hello" || field.New != "hi" {
+			t.Errorf("Changed[0] field = %+v, want hello -> hi", field)
+		}
+	}
+
+	want := []FieldChange{{Field: "Stressed", Old: "1", New: "2"}}
+	if got := diff.Changed[1].Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("Changed[1].Fields = %+v, want %+v", got, want)
+	}
+
+	if diff.Empty() {
+		t.Error("Empty() = true")
+	}
+	if diff := DiffWords(old, old); !diff.Empty() {
+		t.Errorf("DiffWords() of the same words = %+v", diff)
+	}
+}
+
+func TestDiffDictionariesError(t *testing.T) {
+	source := &changingSource{err: errors.New("broken")}
+	if _, err := DiffDictionaries(NewMemorySource(nil), source); err == nil {
+		t.Error("DiffDictionaries() error = nil")
+	}
+}
+
+func wordIDs(words []Word) (ids []string) {
+	for _, word := range words {
+		ids = append(ids, word.ID)
+	}
+	return
+}
@@ -0,0 +1,195 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. download.go fetches the dictionary file.
+package fwew_lib
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// How long DownloadDict waits for the whole download
+const downloadTimeout = 2 * time.Minute
+
+// Every column the dictionary file needs, the other languages are optional
+var dictColumns = []string{"id", "navi", "ipa", "infixes", "partOfSpeech", "source", "stressed", "syllables", "infixDots", "en"}
+
+// Downloader fetches the dictionary file.
+// The zero value downloads from the official URL with http.DefaultClient.
+type Downloader struct {
+	// Where to download from, empty means the official dictionary
+	URL string
+	// The client to download with, nil means http.DefaultClient
+	Client *http.Client
+}
+
+func (dl *Downloader) url() string {
+	if dl.URL != "" {
+		return dl.URL
+	}
+	return Text("dictURL")
+}
+
+func (dl *Downloader) client() *http.Client {
+	if dl.Client != nil {
+		return dl.Client
+	}
+	return http.DefaultClient
+}
+
+// Download saves the newest dictionary to path, give an empty path to update the found dictionary file.
+// If no file is found (e.g. the fallback dictionary is used), an empty path downloads to ~/.fwew/dictionary-v2.txt.
+// If the file at path is already up-to-date (checked with If-None-Match and If-Modified-Since),
+// nothing is downloaded and updated is false.
+// The file is only replaced after the download is checked to be a valid dictionary, it keeps the mode of the old one.
+// Version.DictBuild stays the same, it changes when the default dictionary is loaded again (see UpdateDict).
+func (dl *Downloader) Download(ctx context.Context, path string) (updated bool, err error) {
+	// only try to find dictionary-file if no path is given
+	if path == "" {
+		path = FindDictionaryFile()
+	}
+
+	// none yet, put it where AssureDict would
+	if path == "" {
+		path = filepath.Join(texts["dataDir"], dictFileName)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, dl.url(), nil)
+	if err != nil {
+		return false, err
+	}
+
+	// a new file is readable by everyone, like os.WriteFile(path, data, 0644) makes it
+	mode := os.FileMode(0644)
+
+	// ask for the dictionary only if it changed since we got ours
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+		if etag, err := os.ReadFile(etagFile(path)); err == nil {
+			request.Header.Set("If-None-Match", strings.TrimSpace(string(etag)))
+		}
+		request.Header.Set("If-Modified-Since", info.ModTime().UTC().Format(http.TimeFormat))
+	}
+
+	response, err := dl.client().Do(request)
+	if err != nil {
+		return false, DownloadFailed.wrap(err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return false, nil
+	default:
+		return false, DownloadFailed.wrap(errors.New(response.Status))
+	}
+
+	dir := filepath.Dir(path)
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return false, err
+	}
+
+	// download next to the old file, so it can be swapped in one go
+	temp, err := os.CreateTemp(dir, filepath.Base(path)+".*.download")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(temp.Name())
+
+	_, err = io.Copy(temp, response.Body)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, DownloadFailed.wrap(err)
+	}
+
+	err = checkDictionary(temp.Name())
+	if err != nil {
+		return false, err
+	}
+
+	// CreateTemp makes it owner-only
+	err = os.Chmod(temp.Name(), mode)
+	if err != nil {
+		return false, err
+	}
+
+	// remember the version, for the next If-Modified-Since
+	if modified, err := http.ParseTime(response.Header.Get("Last-Modified")); err == nil {
+		os.Chtimes(temp.Name(), modified, modified)
+	}
+
+	err = os.Rename(temp.Name(), path)
+	if err != nil {
+		return false, err
+	}
+
+	// remember the version, for the next If-None-Match
+	if etag := response.Header.Get("ETag"); etag != "" {
+		os.WriteFile(etagFile(path), []byte(etag), 0644)
+	} else {
+		os.Remove(etagFile(path))
+	}
+
+	return true, nil
+}
+
+// missingColumns lists the columns of dictColumns that are not in the header
+func missingColumns(header []string) (missing []string) {
+	for _, column := range dictColumns {
+		if !ContainsStr(header, column) {
+			missing = append(missing, column)
+		}
+	}
+	return
+}
+
+// The ETag of a downloaded dictionary is saved next to it
+func etagFile(path string) string {
+	return path + ".etag"
+}
+
+// checkDictionary makes sure the file at path is a dictionary FileSource can read,
+// by reading every entry the same way
+func checkDictionary(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entries := 0
+	err = readDictionary(file, func(word Word) error {
+		entries++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if entries == 0 {
+		return InvalidDictionary.wrap(errors.New("no entries"))
+	}
+
+	return nil
+}
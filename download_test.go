@@ -0,0 +1,298 @@
+package fwew_lib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// serves content like the dictionary server does, with an ETag and Last-Modified
+type testDictServer struct {
+	mutex    sync.Mutex
+	content  []byte
+	etag     string
+	modified time.Time
+	status   int
+	requests int
+}
+
+func (s *testDictServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests++
+
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	http.ServeContent(w, r, "dictionary-v2.txt", s.modified, bytes.NewReader(s.content))
+}
+
+func (s *testDictServer) set(content []byte, etag string, modified time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.content = content
+	s.etag = etag
+	s.modified = modified
+}
+
+func TestDownloader(t *testing.T) {
+	first, _ := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	))
+	second, _ := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	))
+
+	server := &testDictServer{}
+	server.set(first, `"one"`, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	downloader := &Downloader{URL: ts.URL, Client: ts.Client()}
+	path := filepath.Join(t.TempDir(), "data", "dictionary-v2.txt")
+	ctx := context.Background()
+
+	// the directory is made and the file is written
+	build := DictBuild()
+	updated, err := downloader.Download(ctx, path)
+	if err != nil || !updated {
+		t.Fatalf("Download() = %v, %v, want true, nil", updated, err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != string(first) {
+		t.Errorf("downloaded file = %q, want %q", got, first)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0644 {
+		t.Errorf("mode of the downloaded file = %v, want 0644", info.Mode().Perm())
+	}
+	// nothing loads it, so it is not the build of the dictionary
+	if DictBuild() != build {
+		t.Errorf("Version.DictBuild after Download() = %q, want %q", DictBuild(), build)
+	}
+
+	// nothing changed
+	updated, err = downloader.Download(ctx, path)
+	if err != nil || updated {
+		t.Errorf("Download() of the same dictionary = %v, %v, want false, nil", updated, err)
+	}
+
+	// a new version, keeping the mode of the old file
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatal(err)
+	}
+	server.set(second, `"two"`, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	updated, err = downloader.Download(ctx, path)
+	if err != nil || !updated {
+		t.Fatalf("Download() of a new dictionary = %v, %v, want true, nil", updated, err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != string(second) {
+		t.Errorf("downloaded file = %q, want %q", got, second)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0640 {
+		t.Errorf("mode of the updated file = %v, want 0640", info.Mode().Perm())
+	}
+
+	// errors keep the old file
+	tests := []struct {
+		name    string
+		status  int
+		content []byte
+		want    error
+	}{
+		{"not found", http.StatusNotFound, nil, DownloadFailed},
+		{"server error", http.StatusInternalServerError, nil, DownloadFailed},
+		{"html", 0, []byte("<html><body>Moved</body></html>"), InvalidDictionary},
+		{"only the header", 0, []byte(testDictHeader + "\n"), InvalidDictionary},
+		{"short line", 0, []byte(testDictHeader + "\n1\ttute\n"), InvalidDictionary},
+		{"short last line", 0, append(append([]byte{}, second...), "3\tkaltxì\n"...), InvalidDictionary},
+		{"no navi column", 0, bytes.Replace(second, []byte("navi"), []byte("name"), 1), InvalidDictionary},
+		{"empty", 0, []byte{}, InvalidDictionary},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.mutex.Lock()
+			server.status = tt.status
+			server.mutex.Unlock()
+			server.set(tt.content, tt.name, time.Date(2024, 3, i+1, 0, 0, 0, 0, time.UTC))
+
+			updated, err := downloader.Download(ctx, path)
+			if !errors.Is(err, tt.want) || updated {
+				t.Errorf("Download() = %v, %v, want false, %v", updated, err, tt.want)
+			}
+			if got, _ := os.ReadFile(path); string(got) != string(second) {
+				t.Errorf("file after a failed download = %q, want %q", got, second)
+			}
+			if matches, _ := filepath.Glob(path + ".*.download"); len(matches) != 0 {
+				t.Errorf("temp files left behind: %v", matches)
+			}
+		})
+	}
+}
+
+func TestDownloaderTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	downloader := &Downloader{URL: ts.URL, Client: ts.Client()}
+	path := filepath.Join(t.TempDir(), "dictionary-v2.txt")
+	updated, err := downloader.Download(ctx, path)
+	if !errors.Is(err, context.DeadlineExceeded) || updated {
+		t.Errorf("Download() = %v, %v, want false, context.DeadlineExceeded", updated, err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file after a timeout: %v", err)
+	}
+}
+
+func TestDictionaryUpdateDictWith(t *testing.T) {
+	content, _ := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	))
+	server := &testDictServer{}
+	// newer than the file we have
+	server.set(content, `"one"`, time.Now().Add(time.Hour))
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	path := writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	)
+	d := NewDictionary(NewFileSource(path))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	downloader := &Downloader{URL: ts.URL, Client: ts.Client()}
+
+	build := DictBuild()
+	diff, err := d.UpdateDictWith(context.Background(), downloader)
+	if err != nil {
+		t.Fatalf("UpdateDictWith() error = %v", err)
+	}
+	if got := d.GetDictSizeSimple(); got != 2 {
+		t.Errorf("GetDictSizeSimple() after the update = %d, want 2", got)
+	}
+	if DictBuild() != build {
+		t.Errorf("Version.DictBuild after updating another dictionary = %q, want %q", DictBuild(), build)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Navi != "kaltxì" || len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+		t.Errorf("UpdateDictWith() diff = %+v, want kaltxì added", diff)
+	}
+
+	// unchanged on the server, so the file isn't read again
+	info, _ := os.Stat(path)
+	old, _ := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	))
+	os.WriteFile(path, old, 0644)
+	os.Chtimes(path, info.ModTime(), info.ModTime())
+	diff, err = d.UpdateDictWith(context.Background(), downloader)
+	if err != nil || !diff.Empty() {
+		t.Fatalf("UpdateDictWith() = %+v, %v, want an empty diff", diff, err)
+	}
+	if got := d.GetDictSizeSimple(); got != 2 {
+		t.Errorf("GetDictSizeSimple() after an update without changes = %d, want 2", got)
+	}
+
+	// a failed download keeps the old dictionary
+	server.mutex.Lock()
+	server.status = http.StatusBadGateway
+	server.mutex.Unlock()
+	if _, err := d.UpdateDictWith(context.Background(), downloader); !errors.Is(err, DownloadFailed) {
+		t.Errorf("UpdateDictWith() error = %v, want DownloadFailed", err)
+	}
+	if got := d.GetDictSizeSimple(); got != 2 {
+		t.Errorf("GetDictSizeSimple() after a failed update = %d, want 2", got)
+	}
+}
+
+// With only the fallback dictionary, the update goes where AssureDict would put the file
+func TestDictionaryUpdateDictFallback(t *testing.T) {
+	if FindDictionaryFile() != "" {
+		t.Skip("a dictionary file is found, the fallback is never used")
+	}
+
+	dataDir := texts["dataDir"]
+	oldFallback := fallbackDictionary
+	t.Cleanup(func() {
+		texts["dataDir"] = dataDir
+		SetFallbackDictionary(oldFallback)
+	})
+	texts["dataDir"] = filepath.Join(t.TempDir(), ".fwew")
+
+	fallback, _ := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	))
+	SetFallbackDictionary(fallback)
+	content, _ := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	))
+	server := &testDictServer{}
+	server.set(content, `"one"`, time.Now())
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	d := NewDictionary(NewFileSource(""))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	diff, err := d.UpdateDictWith(context.Background(), &Downloader{URL: ts.URL, Client: ts.Client()})
+	if err != nil {
+		t.Fatalf("UpdateDictWith() error = %v", err)
+	}
+	if len(diff.Added) != 1 || d.GetDictSizeSimple() != 2 {
+		t.Errorf("UpdateDictWith() diff = %+v, size %d, want kaltxì added", diff, d.GetDictSizeSimple())
+	}
+	if want := filepath.Join(texts["dataDir"], dictFileName); FindDictionaryFile() != want {
+		t.Errorf("FindDictionaryFile() = %q, want %q", FindDictionaryFile(), want)
+	}
+}
+
+// Updating the default dictionary shows the new build in the version
+func TestUpdateDictWithBuild(t *testing.T) {
+	oldDictionary := defaultDictionary
+	build := DictBuild()
+	t.Cleanup(func() {
+		defaultDictionary = oldDictionary
+		setDictBuild(build)
+	})
+
+	content, _ := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	))
+	server := &testDictServer{}
+	server.set(content, `"one"`, time.Now().Add(time.Hour))
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	path := writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	)
+	defaultDictionary = NewDictionary(NewFileSource(path))
+	if err := defaultDictionary.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := UpdateDictWith(context.Background(), &Downloader{URL: ts.URL, Client: ts.Client()}); err != nil {
+		t.Fatalf("UpdateDictWith() error = %v", err)
+	}
+	if want := bytesHash(content); DictBuild() != want {
+		t.Errorf("Version.DictBuild = %q, want %q", DictBuild(), want)
+	}
+}
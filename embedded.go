@@ -0,0 +1,48 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. embedded.go handles the dictionary compiled into the program.
+package fwew_lib
+
+// Used when FindDictionaryFile() finds nothing.
+// Build with the fwew_embed tag or call SetFallbackDictionary to fill it.
+var fallbackDictionary []byte
+
+// SetFallbackDictionary sets the contents of a dictionary file to use when FindDictionaryFile() finds nothing,
+// e.g. one embedded with go:embed.  Programs then work without a download.
+// Give nil to remove it again.
+func SetFallbackDictionary(data []byte) {
+	fallbackDictionary = data
+	setDictBuild(dictBuild())
+}
+
+// HasFallbackDictionary tells if a fallback dictionary is set
+func HasFallbackDictionary() bool {
+	return fallbackDictionary != nil
+}
+
+// the hash of the dictionary the default FileSource reads
+func dictBuild() string {
+	file := FindDictionaryFile()
+	if file != "" {
+		hash, err := fileHash(file)
+		if err == nil {
+			return hash
+		}
+	}
+	if fallbackDictionary != nil {
+		return bytesHash(fallbackDictionary)
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+//go:build fwew_embed
+
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. embedded_dictionary.go compiles dictionary-v2.txt into the program.
+// The dictionary file isn't in the repository, so a plain `go get` doesn't bring it.
+// Fetch it with `go generate -tags fwew_embed` first, then build with `-tags fwew_embed`.
+package fwew_lib
+
+import _ "embed"
+
+//go:generate go run ./misc/getdict dictionary-v2.txt
+
+//go:embed dictionary-v2.txt
+var embeddedDictionary []byte
+
+func init() {
+	SetFallbackDictionary(embeddedDictionary)
+}
@@ -0,0 +1,23 @@
+//go:build fwew_embed
+
+package fwew_lib
+
+import "testing"
+
+func TestEmbeddedDictionary(t *testing.T) {
+	if !HasFallbackDictionary() {
+		t.Fatal("the fwew_embed build has no fallback dictionary")
+	}
+
+	words := 0
+	err := NewEmbeddedSource(embeddedDictionary).Run(func(word Word) error {
+		words++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if words == 0 {
+		t.Error("the embedded dictionary has no words")
+	}
+}
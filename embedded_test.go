@@ -0,0 +1,55 @@
+package fwew_lib
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFallbackDictionary(t *testing.T) {
+	if FindDictionaryFile() != "" {
+		t.Skip("a dictionary file is found, the fallback is never used")
+	}
+
+	old := fallbackDictionary
+	t.Cleanup(func() { SetFallbackDictionary(old) })
+
+	data, err := os.ReadFile(writeTestDict(t,
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetFallbackDictionary(nil)
+	if err := NewFileSource("").Run(func(word Word) error { return nil }); err != DictionaryNotFound {
+		t.Errorf("Run() without a fallback error = %v, want DictionaryNotFound", err)
+	}
+
+	SetFallbackDictionary(data)
+	if !HasFallbackDictionary() {
+		t.Error("HasFallbackDictionary() = false")
+	}
+	if want := bytesHash(data); Version.DictBuild != want {
+		t.Errorf("Version.DictBuild = %q, want %q", Version.DictBuild, want)
+	}
+	if err := AssureDict(); err != nil {
+		t.Errorf("AssureDict() error = %v", err)
+	}
+
+	d := NewDictionary(NewFileSource(""))
+	if err := d.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := d.TranslateToNaviHash("hello", "en"); len(got) != 1 || len(got[0]) != 2 || got[0][1].Navi != "kaltxì" {
+		t.Errorf("TranslateToNaviHash() = %v", got)
+	}
+
+	// the source hash matches what the version shows, so snapshots work too
+	if hash, err := NewFileSource("").Hash(); err != nil || hash != Version.DictBuild {
+		t.Errorf("Hash() = %q, %v, want %q", hash, err, Version.DictBuild)
+	}
+	if hash, _ := NewEmbeddedSource(data).Hash(); hash != Version.DictBuild {
+		t.Errorf("EmbeddedSource Hash() = %q, want %q", hash, Version.DictBuild)
+	}
+}
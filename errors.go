@@ -9,6 +9,19 @@ import (
 const (
 	// cache
 	DictionaryNotFound = constError("no dictionary found")
+	InvalidDictionary  = constError("invalid dictionary")
+	DownloadFailed     = constError("dictionary download failed")
+	// snapshot
+	SnapshotOutdated     = constError("snapshot is outdated")
+	SnapshotNotSupported = constError("dictionary source has no hash for a snapshot")
+	// watcher
+	WatchNotSupported = constError("only dictionary files can be watched")
+	InvalidInterval   = constError("interval must be positive")
+	// json
+	InvalidJSON        = constError("invalid json")
+	SchemaNotSupported = constError("schema version not supported")
+	// conjugate
+	InvalidConjugation = constError("invalid conjugation")
 	// numbers
 	NegativeNumber     = constError("negative numbers not allowed")
 	NumberTooBig       = constError("number too big")
@@ -0,0 +1,187 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Restore the known languages after the test
+func keepLanguages(t *testing.T) {
+	old := Languages()
+	t.Cleanup(func() {
+		languagesLock.Lock()
+		languages = old
+		languagesLock.Unlock()
+	})
+}
+
+func TestFallbackChain(t *testing.T) {
+	keepLanguages(t)
+
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"uk", []string{"uk", "en"}},
+		{"de", []string{"de", "en"}},
+		{"en", []string{"en"}},
+		{"xx", []string{"xx", "en"}},
+	}
+	for _, tt := range tests {
+		if got := FallbackChain(tt.code); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FallbackChain(%s) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+
+	SetFallback("uk", "ru")
+	if got := FallbackChain("uk"); !reflect.DeepEqual(got, []string{"uk", "ru", "en"}) {
+		t.Errorf("FallbackChain(uk) after SetFallback() = %v", got)
+	}
+	SetFallback("nl", "de", "en", "nl")
+	if got := FallbackChain("nl"); !reflect.DeepEqual(got, []string{"nl", "de", "en"}) {
+		t.Errorf("FallbackChain(nl) after SetFallback() = %v", got)
+	}
+	// the fallback stays when the language gets a new name
+	RegisterLanguage(Language{Code: "nl", Name: "Dutch", NativeName: "Nederlands"})
+	if got := FallbackChain("nl"); !reflect.DeepEqual(got, []string{"nl", "de", "en"}) {
+		t.Errorf("FallbackChain(nl) after RegisterLanguage() = %v", got)
+	}
+}
+
+func TestFallbackDefinitions(t *testing.T) {
+	keepLanguages(t)
+	SetFallback("uk", "ru")
+
+	words := testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "ioang", "i.ˈo.aŋ", "NULL", "n.", "2", "i-o-ang", "NULL", "animal"),
+	)
+	words[0].SetDefinition("uk", "NULL")
+	words[0].SetDefinition("ru", "человек")
+	words[1].SetDefinition("uk", "NULL")
+	words[1].SetDefinition("ru", "NULL")
+	words[1].SetDefinition("de", "NULL")
+	d := NewDictionary(NewMemorySource(words))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := d.SearchFromNavi("tute", false, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tute := results[0].Matches[0].Word
+	if definition, from := tute.DefinitionFrom("uk"); definition != "человек" || from != "ru" {
+		t.Errorf("DefinitionFrom(uk) = %q, %q, want the Russian one", definition, from)
+	}
+	if definition, from := tute.DefinitionFrom("de"); definition != "person" || from != "de" {
+		t.Errorf("DefinitionFrom(de) = %q, %q", definition, from)
+	}
+	if !reflect.DeepEqual(tute.Fallbacks, map[string]string{"uk": "ru"}) {
+		t.Errorf("Fallbacks = %v", tute.Fallbacks)
+	}
+
+	results, err = d.SearchFromNavi("ioang", false, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioang := results[0].Matches[0].Word
+	if !reflect.DeepEqual(ioang.Fallbacks, map[string]string{"uk": "en", "ru": "en", "de": "en"}) {
+		t.Errorf("Fallbacks = %v", ioang.Fallbacks)
+	}
+	if definition, from := ioang.DefinitionFrom("uk"); definition != "animal" || from != "en" {
+		t.Errorf("DefinitionFrom(uk) = %q, %q, want the English one", definition, from)
+	}
+
+	// a definition of its own is not a fallback anymore
+	tute.SetDefinition("uk", "людина")
+	if definition, from := tute.DefinitionFrom("uk"); definition != "людина" || from != "uk" {
+		t.Errorf("DefinitionFrom(uk) after SetDefinition() = %q, %q", definition, from)
+	}
+}
+
+func TestFallbackNoDefinition(t *testing.T) {
+	keepLanguages(t)
+	SetFallback("uk", "ru")
+
+	words := testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "NULL"),
+	)
+	words[0].SetDefinition("uk", "NULL")
+	words[0].SetDefinition("ru", "NULL")
+	words[0].SetDefinition("de", "Mensch")
+
+	tute := FallbackIfNull(words[0])
+	for _, code := range []string{"en", "uk", "ru"} {
+		if definition, from := tute.DefinitionFrom(code); definition != "(no definition)" || from != code {
+			t.Errorf("DefinitionFrom(%s) = %q, %q, want no definition", code, definition, from)
+		}
+	}
+	if definition, from := tute.DefinitionFrom("de"); definition != "Mensch" || from != "de" {
+		t.Errorf("DefinitionFrom(de) = %q, %q", definition, from)
+	}
+	if tute.Fallbacks != nil {
+		t.Errorf("Fallbacks = %v, want none", tute.Fallbacks)
+	}
+}
+
+func TestSearchToNaviFallback(t *testing.T) {
+	keepLanguages(t)
+	SetFallback("uk", "ru")
+
+	words := testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "tuté", "ˈtu.tɛ", "NULL", "n.", "1", "tu-té", "NULL", "female person"),
+		testDictRow("3", "ioang", "i.ˈo.aŋ", "NULL", "n.", "2", "i-o-ang", "NULL", "animal"),
+	)
+	words[0].SetDefinition("uk", "людина")
+	words[0].SetDefinition("ru", "человек")
+	words[1].SetDefinition("uk", "NULL")
+	words[1].SetDefinition("ru", "женщина, человек")
+	words[2].SetDefinition("uk", "NULL")
+	words[2].SetDefinition("ru", "NULL")
+	d := NewDictionary(NewMemorySource(words))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query    string
+		want     []string
+		language []string
+	}{
+		{"людина", []string{"tute"}, []string{"uk"}},
+		// tute has a Ukrainian definition, so its Russian one is not searched
+		{"человек", []string{"tuté"}, []string{"ru"}},
+		{"animal", []string{"ioang"}, []string{"en"}},
+		// ioang has no Russian definition, but the others have
+		{"person", nil, nil},
+	}
+	for _, tt := range tests {
+		matches := d.SearchToNavi(tt.query, "uk")[0].Matches
+		var got, language []string
+		for _, match := range matches {
+			got = append(got, match.Word.Navi)
+			language = append(language, match.Language)
+		}
+		if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(language, tt.language) {
+			t.Errorf("SearchToNavi(%s, uk) = %v in %v, want %v in %v", tt.query, got, language, tt.want, tt.language)
+		}
+	}
+
+	// a fallback fits less than a definition of its own
+	own := d.SearchToNavi("людина", "uk")[0].Matches[0].Score
+	fallback := d.SearchToNavi("animal", "uk")[0].Matches[0].Score
+	if own <= fallback {
+		t.Errorf("Score in uk = %v, in en = %v", own, fallback)
+	}
+
+	// the same for both directions
+	results, err := d.SearchBidirectional("человек", false, "uk", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := wordNavis(results[0].Words()); !reflect.DeepEqual(got, []string{"tuté"}) {
+		t.Errorf("SearchBidirectional(человек, uk) = %v", got)
+	}
+}
@@ -149,14 +149,23 @@ func clean(searchNaviWords string) (words string) {
 	return searchNaviWords
 }
 
+// The words to look the Na'vi up in, depending on the spelling rules
+func (d *Dictionary) naviDict(strict bool, allowReef bool) *map[string][]Word {
+	if !allowReef {
+		return &d.hashStrict
+	} else if strict {
+		return &d.hashStrictReef
+	}
+	return &d.hashLoose
+}
+
 // Translate some navi text.
 // !! Multiple words are supported !!
-// This will return a 2D array of Words that fit the input text
-// The first word will only contain the query put into the translate command
+// This will return a SearchResult for every part of the text
 // One Navi-Word can have multiple meanings and words (e.g. synonyms)
-func TranslateFromNaviHash(searchNaviWords string, checkFixes bool, strict bool, allowReef bool) (results [][]Word, err error) {
-	universalLock.Lock()
-	defer universalLock.Unlock()
+func (d *Dictionary) SearchFromNavi(searchNaviWords string, checkFixes bool, strict bool, allowReef bool) (results []SearchResult, err error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	searchNaviWords = clean(searchNaviWords)
 
 	// No Results if empty string after removing sketch chars
@@ -168,15 +177,9 @@ func TranslateFromNaviHash(searchNaviWords string, checkFixes bool, strict bool,
 
 	i := 0
 
-	results = [][]Word{}
+	results = []SearchResult{}
 
-	dict := &dictHashLoose
-
-	if !allowReef {
-		dict = &dictHashStrict
-	} else if strict {
-		dict = &dictHashStrictReef
-	}
+	dict := d.naviDict(strict, allowReef)
 
 	for i < len(allWords) {
 		// Skip empty words or ridiculously long words
@@ -186,19 +189,18 @@ func TranslateFromNaviHash(searchNaviWords string, checkFixes bool, strict bool,
 			i++
 			continue
 		}
-		j, newWords, error2 := TranslateFromNaviHashHelper(dict, i, allWords, checkFixes, strict, allowReef)
+		j, newWords, error2 := d.TranslateFromNaviHashHelper(dict, i, allWords, checkFixes, strict, allowReef)
 		if error2 == nil {
 			for _, newWord := range newWords {
-				// Set up receptacle for words
-				results = append(results, []Word{})
-				results[len(results)-1] = append(results[len(results)-1], newWord...)
+				results = append(results, newSearchResult(newWord, allWords, i, i+j+1))
 			}
 		}
 
-		if len(results[len(results)-1]) > 1 && len(strings.Split(results[len(results)-1][1].Navi, " ")) > 1 {
+		last := &results[len(results)-1]
+		if len(last.Matches) > 0 && len(strings.Split(last.Matches[0].Word.Navi, " ")) > 1 {
 			newQuery := ""
 			kOffset := 0
-			for k := range strings.Split(results[len(results)-1][1].Navi, " ") {
+			for k := range strings.Split(last.Matches[0].Word.Navi, " ") {
 				if i+k+kOffset >= len(allWords) {
 					break
 				}
@@ -213,7 +215,7 @@ func TranslateFromNaviHash(searchNaviWords string, checkFixes bool, strict bool,
 					break
 				}
 			}
-			results[len(results)-1][0].Navi = newQuery
+			last.Query = newQuery
 		}
 		i += j
 		i++
@@ -222,6 +224,14 @@ func TranslateFromNaviHash(searchNaviWords string, checkFixes bool, strict bool,
 	return
 }
 
+// Translate some navi text, see SearchFromNavi.
+// This will return a 2D array of Words that fit the input text
+// The first word will only contain the query put into the translate command
+func (d *Dictionary) TranslateFromNaviHash(searchNaviWords string, checkFixes bool, strict bool, allowReef bool) (results [][]Word, err error) {
+	searchResults, err := d.SearchFromNavi(searchNaviWords, checkFixes, strict, allowReef)
+	return flattenResults(searchResults), err
+}
+
 // Helper for TranslateFromNaviHashHelper
 func AppendToFront(words []Word, input Word) []Word {
 	// Ensure it's not a duplicate
@@ -247,10 +257,10 @@ func AppendToFront(words []Word, input Word) []Word {
 }
 
 // Helper for TranslateFromNaviHashHelper
-func IsVerb(dict *map[string][]Word, input string, comparator string, strict bool, allowReef bool) (result bool, affixes Word) {
+func (d *Dictionary) IsVerb(dict *map[string][]Word, input string, comparator string, strict bool, allowReef bool) (result bool, affixes Word) {
 	affixes = simpleWord(input)
-	_, possibilities, err := TranslateFromNaviHashHelper(dict, 0, []string{input}, true, strict, allowReef)
-	_, possibilities2, err2 := TranslateFromNaviHashHelper(dict, 0, []string{comparator}, true, strict, allowReef)
+	_, possibilities, err := d.TranslateFromNaviHashHelper(dict, 0, []string{input}, true, strict, allowReef)
+	_, possibilities2, err2 := d.TranslateFromNaviHashHelper(dict, 0, []string{comparator}, true, strict, allowReef)
 	if err != nil || err2 != nil {
 		return false, affixes
 	}
@@ -320,7 +330,7 @@ func IsVerb(dict *map[string][]Word, input string, comparator string, strict boo
 	return (isRealVerb && pairFound && !unknownInfix), affixes
 }
 
-func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []string, checkFixes bool, strict bool, allowReef bool) (steps int, results [][]Word, err error) {
+func (d *Dictionary) TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []string, checkFixes bool, strict bool, allowReef bool) (steps int, results [][]Word, err error) {
 	i := start
 
 	containsUmlaut := []bool{}
@@ -348,7 +358,7 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 
 		results = [][]Word{{simpleWord(allWords[i])}}
 
-		allWords = dialectCrunch(allWords, false, strict, allowReef)
+		allWords = d.dialectCrunch(allWords, false, strict, allowReef)
 
 		searchNaviWord = allWords[i]
 
@@ -415,11 +425,11 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 	//if !bareNaviWord {
 	found := false
 	// See if it is in the list known to start multiword words
-	multiwords := &multiword_words
+	multiwords := &d.multiwords
 	if !strict {
-		multiwords = &multiword_words_loose
+		multiwords = &d.multiwordsLoose
 	} else if allowReef {
-		multiwords = &multiword_words_reef
+		multiwords = &d.multiwordsReef
 	}
 	if _, ok := (*multiwords)[searchNaviWord]; ok {
 		// If so, loop through it
@@ -442,7 +452,7 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 				} else {
 					// For "[word] ke si and [word] rä'ä si"
 					if i+j+2 < len(allWords) && (allWords[i+j+1] == "ke" || allWords[i+j+1] == "rä'ä") {
-						validVerb, itsAffixes := IsVerb(dict, allWords[i+j+2], pairWord, strict, allowReef)
+						validVerb, itsAffixes := d.IsVerb(dict, allWords[i+j+2], pairWord, strict, allowReef)
 						if validVerb {
 							extraWord = 1
 							if len(results) == 1 {
@@ -461,7 +471,7 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 					}
 
 					// Verbs don't just come after ke or rä'ä
-					validVerb, itsAffixes := IsVerb(dict, allWords[i+j+1], pairWord, strict, allowReef)
+					validVerb, itsAffixes := d.IsVerb(dict, allWords[i+j+1], pairWord, strict, allowReef)
 					if validVerb {
 						found = true
 						foundAlready = true
@@ -481,7 +491,7 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 					}
 
 					// And then by its possible conjugations
-					for _, b := range TestDeconjugations(dict, allWords[i+j+1], strict, allowReef, containsUmlaut[i]) {
+					for _, b := range d.TestDeconjugations(dict, allWords[i+j+1], strict, allowReef, containsUmlaut[i]) {
 						breakAdding := false
 						for _, prefix := range verbPrefixes {
 							for _, ourPrefixes := range b.Affixes.Prefix {
@@ -566,11 +576,11 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 			if len(results) > 0 && len(results[0]) > 0 {
 				if !(strings.ToLower(results[len(results)-1][0].Navi) != searchNaviWord && strings.HasPrefix(strings.ToLower(results[len(results)-1][0].Navi), searchNaviWord)) {
 					// Find all possible unconjugated versions of the word
-					newResults = TestDeconjugations(dict, searchNaviWord, strict, allowReef, containsUmlaut[i])
+					newResults = d.TestDeconjugations(dict, searchNaviWord, strict, allowReef, containsUmlaut[i])
 				}
 			} else {
 				// Find all possible unconjugated versions of the word
-				newResults = TestDeconjugations(dict, searchNaviWord, strict, allowReef, containsUmlaut[i])
+				newResults = d.TestDeconjugations(dict, searchNaviWord, strict, allowReef, containsUmlaut[i])
 			}
 		}
 
@@ -678,7 +688,7 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 							} else {
 								// For "[word] ke si and [word] rä'ä si"
 								if i+j+2 < len(allWords) && (allWords[i+j+1] == "ke" || allWords[i+j+1] == "ree") {
-									validVerb, itsAffixes := IsVerb(dict, allWords[i+j+2], pairWord, strict, allowReef)
+									validVerb, itsAffixes := d.IsVerb(dict, allWords[i+j+2], pairWord, strict, allowReef)
 									if validVerb {
 										extraWord = 1
 										if len(results) == 1 {
@@ -703,8 +713,8 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 								allWord := allWords[i+j+1]
 
 								if !strict || allowReef {
-									pairWord = dialectCrunch([]string{pairWord}, false, strict, allowReef)[0]
-									allWord = dialectCrunch([]string{allWord}, false, strict, allowReef)[0]
+									pairWord = d.dialectCrunch([]string{pairWord}, false, strict, allowReef)[0]
+									allWord = d.dialectCrunch([]string{allWord}, false, strict, allowReef)[0]
 								}
 
 								// First by itself
@@ -715,7 +725,7 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 								}
 
 								// And then by its possible conjugations
-								for _, b := range TestDeconjugations(dict, allWords[i+j+1], strict, allowReef, containsUmlaut[i]) {
+								for _, b := range d.TestDeconjugations(dict, allWords[i+j+1], strict, allowReef, containsUmlaut[i]) {
 									breakAdding := false
 									for _, prefix := range verbPrefixes {
 										for _, ourPrefixes := range b.Affixes.Prefix {
@@ -772,7 +782,7 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 							results[0] = []Word{results[0][0]}
 							a := strings.ReplaceAll(fullWord, "ù", "u")
 							if !strict {
-								a = dialectCrunch([]string{a}, false, strict, allowReef)[0]
+								a = d.dialectCrunch([]string{a}, false, strict, allowReef)[0]
 							}
 
 							for _, definition := range (*dict)[a] {
@@ -810,7 +820,7 @@ func TranslateFromNaviHashHelper(dict *map[string][]Word, start int, allWords []
 	return i - start, results, nil
 }
 
-func SearchNatlangWord(wordmap map[string][]string, searchWord string) (results []Word) {
+func (d *Dictionary) SearchNatlangWord(wordmap map[string][]string, searchWord string) (results []Word) {
 
 	// No Results if empty string after removing sketch chars
 	if len(searchWord) == 0 {
@@ -825,7 +835,7 @@ func SearchNatlangWord(wordmap map[string][]string, searchWord string) (results
 	firstResults := wordmap[searchWord]
 
 	for i := 0; i < len(firstResults); i++ {
-		for _, c := range dictHashStrict[firstResults[i]] {
+		for _, c := range d.hashStrict[firstResults[i]] {
 			results = AppendAndAlphabetize(results, c)
 		}
 	}
@@ -833,271 +843,132 @@ func SearchNatlangWord(wordmap map[string][]string, searchWord string) (results
 	return
 }
 
-func TranslateToNaviHash(searchWord string, langCode string) (results [][]Word) {
-	universalLock.Lock()
-	defer universalLock.Unlock()
+// Find the Na'vi words for every word of the text.
+// This will return a SearchResult for every word
+func (d *Dictionary) SearchToNavi(searchWord string, langCode string) (results []SearchResult) {
+	return d.SearchToNaviWith(searchWord, langCode, NatlangOptions{})
+}
+
+// Find the Na'vi words for every word of the text, see NatlangOptions.
+// This will return a SearchResult for every word
+func (d *Dictionary) SearchToNaviWith(searchWord string, langCode string, options NatlangOptions) (results []SearchResult) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	searchWord = clean(searchWord)
 
-	results = [][]Word{}
+	results = []SearchResult{}
 
-	for _, word := range strings.Split(searchWord, " ") {
+	for i, word := range strings.Split(searchWord, " ") {
 		// Skip empty words
 		if len(word) == 0 {
 			continue
 		}
-		results = append(results, []Word{})
-		for _, a := range TranslateToNaviHashHelper(&dictHash2Parenthesis, word, langCode) {
-			results[len(results)-1] = AppendAndAlphabetize(results[len(results)-1], a)
-		}
-		// Append the query to the front of the list
-		tempResults := []Word{simpleWord(word)}
-		tempResults = append(tempResults, results[len(results)-1]...)
-		results[len(results)-1] = tempResults
+		results = append(results, SearchResult{
+			Query:   word,
+			Start:   i,
+			End:     i + 1,
+			Matches: d.searchNatlang(&d.hash2Parenthesis, word, langCode, options),
+		})
 	}
 	return
 }
 
-func TranslateToNaviHashHelper(dictionary *MetaDict, searchWord string, langCode string) (results []Word) {
+// Find the Na'vi words for some text, see SearchToNavi.
+// The first word of every list will only contain the query
+func (d *Dictionary) TranslateToNaviHash(searchWord string, langCode string) (results [][]Word) {
+	return flattenResults(d.SearchToNavi(searchWord, langCode))
+}
+
+func (d *Dictionary) TranslateToNaviHashHelper(dictionary *MetaDict, searchWord string, langCode string) (results []Word) {
 	results = []Word{}
-	switch langCode {
-	case "de": // German
-		for _, a := range SearchNatlangWord((*dictionary).DE, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.DE, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "en": // English
-		for _, a := range SearchNatlangWord((*dictionary).EN, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.EN, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "es": // Spanish
-		for _, a := range SearchNatlangWord((*dictionary).ES, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.ES, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "et": // Estonian
-		for _, a := range SearchNatlangWord((*dictionary).ET, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.ET, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "fr": // French
-		for _, a := range SearchNatlangWord((*dictionary).FR, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.FR, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "hu": // Hungarian
-		for _, a := range SearchNatlangWord((*dictionary).HU, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.HU, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "ko": // Korean
-		for _, a := range SearchNatlangWord((*dictionary).KO, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.KO, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "nl": // Dutch
-		for _, a := range SearchNatlangWord((*dictionary).NL, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.NL, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "pl": // Polish
-		for _, a := range SearchNatlangWord((*dictionary).PL, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.PL, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
-		}
-	case "pt": // Portuguese
-		for _, a := range SearchNatlangWord((*dictionary).PT, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.PT, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
+
+	// If we get an odd language code, return English
+	terms, ok := (*dictionary)[langCode]
+	if !ok {
+		langCode = "en"
+		terms = (*dictionary)[langCode]
+	}
+
+	for _, a := range d.SearchNatlangWord(terms, searchWord) {
+		// Verify the search query is actually in the definition
+		searchWords := SearchTerms(a.Definitions[langCode], false)
+		found := false
+		for _, d := range searchWords {
+			if d == searchWord {
+				found = true
+				break
 			}
 		}
-	case "ru": // Russian
-		for _, a := range SearchNatlangWord((*dictionary).RU, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.RU, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
+		if found {
+			results = AppendAndAlphabetize(results, a)
 		}
-	case "sv": // Swedish
-		for _, a := range SearchNatlangWord((*dictionary).SV, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.SV, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
+	}
+
+	return
+}
+
+// The language of the definitions searched for langCode.
+// If there are none in that language, the first language of its FallbackChain that has some.
+func (d *Dictionary) searchLanguage(langCode string) string {
+	for _, code := range FallbackChain(langCode) {
+		if _, ok := d.hash2[code]; ok {
+			return code
 		}
-	case "tr": // Turkish
-		for _, a := range SearchNatlangWord((*dictionary).TR, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.TR, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
+	}
+	return "en"
+}
+
+// Find the words with term in their definition, alphabetized.
+// Words without a definition in langCode are found by the definition they fall back to, see FallbackChain.
+func (d *Dictionary) searchNatlang(dictionary *MetaDict, term string, langCode string, options NatlangOptions) (matches []Match) {
+	primary := d.searchLanguage(langCode)
+
+	var normalize func(term string) string
+	if options.Normalize {
+		normalize = normalizer(primary)
+	}
+
+	words := []Word{}
+	from := map[string]string{}
+	for _, code := range FallbackChain(primary) {
+		if _, ok := d.hash2[code]; !ok {
+			continue
 		}
-	case "uk": // Ukrainian
-		for _, a := range SearchNatlangWord((*dictionary).UK, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.UK, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
-			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
-			}
+		var found []Word
+		if options.Normalize {
+			found = d.searchNormalized(term, code)
+		} else {
+			found = d.TranslateToNaviHashHelper(dictionary, term, code)
 		}
-	default:
-		// If we get an odd language code, return English
-		for _, a := range SearchNatlangWord((*dictionary).EN, searchWord) {
-			// Verify the search query is actually in the definition
-			searchWords := SearchTerms(a.EN, false)
-			found := false
-			for _, d := range searchWords {
-				if d == searchWord {
-					found = true
-					break
-				}
+		for _, a := range found {
+			// words with their own definition were already searched
+			if code != primary && a.Fallbacks[primary] != code {
+				continue
 			}
-			if found {
-				results = AppendAndAlphabetize(results, a)
+			if _, ok := from[a.ID]; !ok {
+				from[a.ID] = code
 			}
+			words = AppendAndAlphabetize(words, a)
 		}
 	}
 
+	for _, a := range words {
+		score := natlangScore(term, a.Definitions[from[a.ID]], normalize)
+		if from[a.ID] != primary {
+			score = max(score-scoreFallback, scoreMin)
+		}
+		matches = append(matches, Match{Word: a, Direction: ToNavi, Score: score, Language: from[a.ID]})
+	}
 	return
 }
 
 // Translate some text.  The language context is with Eywa now :ipu:
 // !! Multiple words are supported !!
-// This will return a 2D array of Words, that fit the input text
+// This will return a SearchResult for every part of the text, with the Na'vi matches first
 // One Word can have multiple meanings and words (e.g. synonyms)
-func BidirectionalSearch(searchNaviWords string, checkFixes bool, langCode string, allowReef bool) (results [][]Word, err error) {
-	universalLock.Lock()
-	defer universalLock.Unlock()
+func (d *Dictionary) SearchBidirectional(searchNaviWords string, checkFixes bool, langCode string, allowReef bool) (results []SearchResult, err error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	searchNaviWords = clean(searchNaviWords)
 
 	// No Results if empty string after removing sketch chars
@@ -1109,22 +980,20 @@ func BidirectionalSearch(searchNaviWords string, checkFixes bool, langCode strin
 
 	i := 0
 
-	ourDict := &dictHashLoose
+	ourDict := &d.hashLoose
 	if !allowReef {
-		ourDict = &dictHashStrict
+		ourDict = &d.hashStrict
 	}
 
-	results = [][]Word{}
+	results = []SearchResult{}
 	for i < len(allWords) {
 		// Search for Na'vi words
-		j, newWords, error2 := TranslateFromNaviHashHelper(ourDict, i, allWords, checkFixes, false, allowReef)
+		j, newWords, error2 := d.TranslateFromNaviHashHelper(ourDict, i, allWords, checkFixes, false, allowReef)
 
 		NaviIDs := []string{}
 		if error2 == nil {
 			for _, newWord := range newWords {
-				// Set up receptacle for words
-				results = append(results, []Word{})
-				results[len(results)-1] = append(results[len(results)-1], newWord...)
+				results = append(results, newSearchResult(newWord, allWords, i, i+j+1))
 				if len(newWord) > 1 {
 					NaviIDs = append(NaviIDs, newWord[1].ID)
 				}
@@ -1132,19 +1001,17 @@ func BidirectionalSearch(searchNaviWords string, checkFixes bool, langCode strin
 		}
 
 		// Search for natural language words
-		natlangWords := []Word{}
-		for _, a := range TranslateToNaviHashHelper(&dictHash2, allWords[i], langCode) {
+		// We want them alphabetized with their fellow natlang words...
+		last := &results[len(results)-1]
+		for _, match := range d.searchNatlang(&d.hash2, allWords[i], langCode, NatlangOptions{}) {
 			// Do not duplicate if the Na'vi word is in the definition
-			if implContainsAny(NaviIDs, []string{a.ID}) {
+			if implContainsAny(NaviIDs, []string{match.Word.ID}) {
 				continue
 			}
-			// We want them alphabetized with their fellow natlang words...
-			natlangWords = AppendAndAlphabetize(natlangWords, a)
+			// ...but not with the Na'vi words
+			last.Matches = append(last.Matches, match)
 		}
 
-		// ...but not with the Na'vi words
-		results[len(results)-1] = append(results[len(results)-1], natlangWords...)
-
 		i += j
 
 		i++
@@ -1152,11 +1019,19 @@ func BidirectionalSearch(searchNaviWords string, checkFixes bool, langCode strin
 	return
 }
 
+// Translate some text, see SearchBidirectional.
+// This will return a 2D array of Words, that fit the input text
+// The first word will only contain the query
+func (d *Dictionary) BidirectionalSearch(searchNaviWords string, checkFixes bool, langCode string, allowReef bool) (results [][]Word, err error) {
+	searchResults, err := d.SearchBidirectional(searchNaviWords, checkFixes, langCode, allowReef)
+	return flattenResults(searchResults), err
+}
+
 // Get random words out of the dictionary.
 // If args are applied, the dict will be filtered for args before random words are chosen.
 // args will be put into the `List()` algorithm.
-func Random(amount int, args []string, checkDigraphs uint8) (results []Word, err error) {
-	allWords, err := List(args, checkDigraphs)
+func (d *Dictionary) Random(amount int, args []string, checkDigraphs uint8) (results []Word, err error) {
+	allWords, err := d.List(args, checkDigraphs)
 
 	if err != nil {
 		log.Printf("Error getting fullDing: %s", err)
@@ -1189,25 +1064,34 @@ func Random(amount int, args []string, checkDigraphs uint8) (results []Word, err
 }
 
 // Get all words with spaces
-func GetMultiwordWords() map[string][][]string {
-	universalLock.Lock()
-	defer universalLock.Unlock()
-	return multiword_words
+func (d *Dictionary) GetMultiwordWords() map[string][][]string {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.multiwords
 }
 
 // Get all words with multiple definitions
-func GetHomonyms() (results [][]Word, err error) {
-	return TranslateFromNaviHash(homonyms, false, false, false)
+func (d *Dictionary) GetHomonyms() (results [][]Word, err error) {
+	d.lock.RLock()
+	homonyms := d.homonyms
+	d.lock.RUnlock()
+	return d.TranslateFromNaviHash(homonyms, false, false, false)
 }
 
 // Get all words with non-standard phonotactics
-func GetOddballs() (results [][]Word, err error) {
-	return TranslateFromNaviHash(oddballs, true, false, false)
+func (d *Dictionary) GetOddballs() (results [][]Word, err error) {
+	d.lock.RLock()
+	oddballs := d.oddballs
+	d.lock.RUnlock()
+	return d.TranslateFromNaviHash(oddballs, true, false, false)
 }
 
 // Get all words with multiple definitions
-func GetMultiIPA() (results [][]Word, err error) {
-	return TranslateFromNaviHash(multiIPA, false, false, false)
+func (d *Dictionary) GetMultiIPA() (results [][]Word, err error) {
+	d.lock.RLock()
+	multiIPA := d.multiIPA
+	d.lock.RUnlock()
+	return d.TranslateFromNaviHash(multiIPA, false, false, false)
 }
 
 /* Is it a vowel? (for when the psuedovowel bool won't work) */
@@ -1223,14 +1107,14 @@ func is_vowel_ipa(letter string) (found bool) {
 	return false
 }
 
-func dialectCrunch(query []string, guaranteedForest bool, strict bool, allowReef bool) []string {
+func (d *Dictionary) dialectCrunch(query []string, guaranteedForest bool, strict bool, allowReef bool) []string {
 	newQuery := []string{}
 	for _, a := range query {
 		oldQuery := a
 
 		// When caching, we are guaranteed forest words and don't need anything in this block
 		if !guaranteedForest && allowReef {
-			for i, b := range nkx {
+			for i, b := range d.nkx {
 				// make sure words like tìkankxan show up
 				a = strings.ReplaceAll(a, strconv.Itoa(i), "")
 				a = strings.ReplaceAll(a, b, strconv.Itoa(i))
@@ -1246,9 +1130,9 @@ func dialectCrunch(query []string, guaranteedForest bool, strict bool, allowReef
 			a = strings.ReplaceAll(a, "ch", "tsy")
 			a = strings.ReplaceAll(a, "sh", "sy")
 			a = strings.ReplaceAll(a, "?", "ng")
-			for i, b := range nkx {
+			for i, b := range d.nkx {
 				// make sure words like tìkankxan show up
-				a = strings.ReplaceAll(a, strconv.Itoa(i), nkxSub[b])
+				a = strings.ReplaceAll(a, strconv.Itoa(i), d.nkxSub[b])
 			}
 		}
 
@@ -1562,21 +1446,33 @@ func ReefMe(ipa string, inter bool) []string {
 }
 
 func StartEverything() string {
-	universalLock.Lock()
 	start := time.Now()
-	var errors = []error{
-		AssureDict(),
-		CacheDict(),
-		CacheDictHash(),
-		CacheDictHash2(),
-	}
-	for _, err := range errors {
-		if err != nil {
+	defaultDictionary.reloadLock.Lock()
+	// only a dictionary file we have to find ourselves can be missing
+	if file, ok := defaultDictionary.Source().(*FileSource); ok && file.Path == "" {
+		if err := AssureDict(); err != nil {
 			log.Println(err)
 		}
 	}
-	universalLock.Unlock()
-	PhonemeDistros()
+
+	// Read the dictionary once and count the phonemes for the name generator on the way
+	phonoLock.Lock()
+	resetPhonemeDistros()
+	err := defaultDictionary.reload(countPhonemes)
+	if err != nil {
+		log.Println(err)
+		// count the dictionary still in use again
+		resetPhonemeDistros()
+		defaultDictionary.RunOnDict(func(word Word) error {
+			countPhonemes(word)
+			return nil
+		})
+	}
+	finishPhonemeDistros()
+	phonoLock.Unlock()
+
+	name := defaultDictionary.Source().Name()
+	defaultDictionary.reloadLock.Unlock()
 	elapsed := strconv.FormatFloat(time.Since(start).Seconds(), 'f', -1, 64)
-	return fmt.Sprintln("Everything is cached.  Took " + elapsed + " seconds")
+	return fmt.Sprintln("Everything is cached (" + name + ").  Took " + elapsed + " seconds")
 }
@@ -0,0 +1,156 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. json.go is the wire format of words and search results.
+package fwew_lib
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is the version of the JSON written by MarshalWord, MarshalResults and MarshalCandidates.
+// It changes whenever a field is renamed or removed, new fields don't change it.
+const SchemaVersion = 1
+
+// What the Marshal functions write: {"Schema": 1, "Data": ...}
+type encoded[T any] struct {
+	Schema int
+	Data   T
+}
+
+func marshalEncoded[T any](data T) ([]byte, error) {
+	return json.Marshal(encoded[T]{Schema: SchemaVersion, Data: data})
+}
+
+func unmarshalEncoded[T any](data []byte) (v T, err error) {
+	var e encoded[T]
+	if err = json.Unmarshal(data, &e); err != nil {
+		return v, InvalidJSON.wrap(err)
+	}
+	if e.Schema != SchemaVersion {
+		return v, SchemaNotSupported.wrap(fmt.Errorf("got %d, want %d", e.Schema, SchemaVersion))
+	}
+	return e.Data, nil
+}
+
+// MarshalWord encodes a single word with the schema version
+func MarshalWord(word Word) ([]byte, error) {
+	return marshalEncoded(word)
+}
+
+// UnmarshalWord decodes what MarshalWord encoded
+func UnmarshalWord(data []byte) (Word, error) {
+	return unmarshalEncoded[Word](data)
+}
+
+// MarshalResults encodes the results of TranslateFromNaviHash, TranslateToNaviHash or BidirectionalSearch
+func MarshalResults(results [][]Word) ([]byte, error) {
+	return marshalEncoded(results)
+}
+
+// UnmarshalResults decodes what MarshalResults encoded
+func UnmarshalResults(data []byte) ([][]Word, error) {
+	return unmarshalEncoded[[][]Word](data)
+}
+
+// MarshalCandidates encodes the results of Deconjugate
+func MarshalCandidates(candidates []ConjugationCandidate) ([]byte, error) {
+	return marshalEncoded(candidates)
+}
+
+// UnmarshalCandidates decodes what MarshalCandidates encoded
+func UnmarshalCandidates(data []byte) ([]ConjugationCandidate, error) {
+	return unmarshalEncoded[[]ConjugationCandidate](data)
+}
+
+// Lists are always written as arrays, never as null, and empty arrays are read back as nil
+type jsonList []string
+
+func (l jsonList) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(l))
+}
+
+func (l *jsonList) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		list = nil
+	}
+	*l = list
+	return nil
+}
+
+type jsonAffixes struct {
+	Prefix   jsonList
+	Infix    jsonList
+	Suffix   jsonList
+	Lenition jsonList
+	Comment  jsonList
+}
+
+func (a affix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonAffixes{a.Prefix, a.Infix, a.Suffix, a.Lenition, a.Comment})
+}
+
+func (a *affix) UnmarshalJSON(data []byte) error {
+	var affixes jsonAffixes
+	if err := json.Unmarshal(data, &affixes); err != nil {
+		return err
+	}
+	*a = affix{
+		Prefix:   affixes.Prefix,
+		Infix:    affixes.Infix,
+		Suffix:   affixes.Suffix,
+		Lenition: affixes.Lenition,
+		Comment:  affixes.Comment,
+	}
+	return nil
+}
+
+type jsonCandidate struct {
+	Word      string
+	Lenition  jsonList
+	Prefixes  jsonList
+	Suffixes  jsonList
+	Infixes   jsonList
+	InsistPOS string
+	Trace     []TraceStep `json:",omitempty"`
+}
+
+func (c ConjugationCandidate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonCandidate{c.Word, c.Lenition, c.Prefixes, c.Suffixes, c.Infixes, c.InsistPOS, c.Trace})
+}
+
+func (c *ConjugationCandidate) UnmarshalJSON(data []byte) error {
+	var candidate jsonCandidate
+	if err := json.Unmarshal(data, &candidate); err != nil {
+		return err
+	}
+	*c = ConjugationCandidate{
+		Word:      candidate.Word,
+		Lenition:  candidate.Lenition,
+		Prefixes:  candidate.Prefixes,
+		Suffixes:  candidate.Suffixes,
+		Infixes:   candidate.Infixes,
+		InsistPOS: candidate.InsistPOS,
+		Trace:     candidate.Trace,
+	}
+	return nil
+}
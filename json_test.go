@@ -0,0 +1,97 @@
+package fwew_lib
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalResults(t *testing.T) {
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := d.TranslateFromNaviHash("tuteti kaltxì", true, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || len(results[0]) != 2 || len(results[0][1].Affixes.Suffix) != 1 {
+		t.Fatalf("TranslateFromNaviHash() = %v", results)
+	}
+
+	data, err := MarshalResults(results)
+	if err != nil {
+		t.Fatalf("MarshalResults() error = %v", err)
+	}
+	for _, want := range []string{`"Schema":1`, `"Definitions":{`, `"Suffix":["ti"]`, `"Prefix":[]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("MarshalResults() = %s, want %s in it", data, want)
+		}
+	}
+
+	decoded, err := UnmarshalResults(data)
+	if err != nil {
+		t.Fatalf("UnmarshalResults() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, results) {
+		t.Errorf("UnmarshalResults() = %v, want %v", decoded, results)
+	}
+	again, _ := MarshalResults(decoded)
+	if string(again) != string(data) {
+		t.Errorf("MarshalResults() of the decoded results = %s, want %s", again, data)
+	}
+}
+
+func TestMarshalWord(t *testing.T) {
+	word := testWords(testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"))[0]
+	word.Affixes.Prefix = []string{}
+
+	data, err := MarshalWord(word)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := UnmarshalWord(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(word) || decoded.Affixes.Prefix != nil {
+		t.Errorf("UnmarshalWord() = %+v, want %+v", decoded, word)
+	}
+}
+
+func TestMarshalCandidates(t *testing.T) {
+	candidates := []ConjugationCandidate{
+		{Word: "tute", Suffixes: []string{"ti"}, InsistPOS: "n."},
+		{Word: "taron", Infixes: []string{"am"}},
+		{Word: "tute", Prefixes: []string{"ay"}, Lenition: []string{"t→s"}, Trace: []TraceStep{
+			{TracePrefix, "ay", "aysute", "sute"},
+			{TraceLenition, "t→s", "sute", "tute"},
+		}},
+	}
+
+	data, err := MarshalCandidates(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `{"Word":"tute","Lenition":[],"Prefixes":[],"Suffixes":["ti"],"Infixes":[],"InsistPOS":"n."}`) {
+		t.Errorf("MarshalCandidates() = %s", data)
+	}
+	decoded, err := UnmarshalCandidates(data)
+	if err != nil || !reflect.DeepEqual(decoded, candidates) {
+		t.Errorf("UnmarshalCandidates() = %+v, %v, want %+v", decoded, err, candidates)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	if _, err := UnmarshalResults([]byte(`{"Schema":2,"Data":[]}`)); !errors.Is(err, SchemaNotSupported) {
+		t.Errorf("UnmarshalResults() of another schema error = %v", err)
+	}
+	if _, err := UnmarshalWord([]byte(`{"Schema":1,"Data":[]}`)); !errors.Is(err, InvalidJSON) {
+		t.Errorf("UnmarshalWord() of a list error = %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. language.go knows the languages of the definitions.
+package fwew_lib
+
+import (
+	"slices"
+	"sync"
+)
+
+// Language is a language the definitions can be in
+type Language struct {
+	// The column in the dictionary file and the languageCode in the database, e.g. "de"
+	Code string
+	// Name in English, e.g. "German"
+	Name string
+	// Name in the language itself, e.g. "Deutsch"
+	NativeName string
+	// Normalize makes the forms of a lowercase word the same, for NatlangOptions.Normalize.
+	// nil only folds the case.  Register it before loading the dictionary.
+	Normalize func(term string) string
+	// Fallback are the languages shown and searched, in this order, if a word has no definition in this one.
+	// English always comes last.  Set it before loading the dictionary, see SetFallback.
+	Fallback []string
+}
+
+var (
+	languagesLock sync.RWMutex
+	// in the order of the dictionary file
+	languages = []Language{
+		{Code: "de", Name: "German", NativeName: "Deutsch", Normalize: normalizeGerman},
+		{Code: "en", Name: "English", NativeName: "English", Normalize: normalizeEnglish},
+		{Code: "es", Name: "Spanish", NativeName: "Español", Normalize: normalizeSpanish},
+		{Code: "et", Name: "Estonian", NativeName: "Eesti", Normalize: foldAccents},
+		{Code: "fr", Name: "French", NativeName: "Français", Normalize: normalizeFrench},
+		{Code: "hu", Name: "Hungarian", NativeName: "Magyar", Normalize: foldAccents},
+		{Code: "it", Name: "Italian", NativeName: "Italiano", Normalize: normalizeItalian},
+		{Code: "ko", Name: "Korean", NativeName: "한국어"},
+		{Code: "nl", Name: "Dutch", NativeName: "Nederlands", Normalize: normalizeDutch},
+		{Code: "pl", Name: "Polish", NativeName: "Polski", Normalize: foldAccents},
+		{Code: "pt", Name: "Portuguese", NativeName: "Português", Normalize: normalizeSpanish},
+		{Code: "ru", Name: "Russian", NativeName: "Русский", Normalize: foldCyrillic},
+		{Code: "sv", Name: "Swedish", NativeName: "Svenska", Normalize: foldAccents},
+		{Code: "tr", Name: "Turkish", NativeName: "Türkçe", Normalize: foldAccents},
+		{Code: "uk", Name: "Ukrainian", NativeName: "Українська", Normalize: foldCyrillic},
+	}
+)
+
+// Languages lists every configured language: the built-in ones and the registered ones.
+// Languages only found in a dictionary are not in it, see Dictionary.LanguageCodes.
+func Languages() []Language {
+	languagesLock.RLock()
+	defer languagesLock.RUnlock()
+	return append([]Language{}, languages...)
+}
+
+// LanguageCodes lists the code of every configured language
+func LanguageCodes() []string {
+	languagesLock.RLock()
+	defer languagesLock.RUnlock()
+	codes := make([]string, len(languages))
+	for i, language := range languages {
+		codes[i] = language.Code
+	}
+	return codes
+}
+
+// LookupLanguage finds a configured language by its code
+func LookupLanguage(code string) (Language, bool) {
+	languagesLock.RLock()
+	defer languagesLock.RUnlock()
+	for _, language := range languages {
+		if language.Code == code {
+			return language, true
+		}
+	}
+	return Language{}, false
+}
+
+// RegisterLanguage adds a language, or updates a known one.
+// A nil Normalize or Fallback keeps the known one.
+// Languages found in a dictionary work without it, this gives them a proper name.
+func RegisterLanguage(language Language) {
+	languagesLock.Lock()
+	defer languagesLock.Unlock()
+	for i, known := range languages {
+		if known.Code == language.Code {
+			if language.Normalize == nil {
+				language.Normalize = known.Normalize
+			}
+			if language.Fallback == nil {
+				language.Fallback = known.Fallback
+			}
+			languages[i] = language
+			return
+		}
+	}
+	languages = append(languages, language)
+}
+
+// SetFallback sets the languages used if a word has no definition in the language with the code,
+// e.g. SetFallback("uk", "ru").  Without any, it falls back to English only.
+func SetFallback(code string, fallback ...string) {
+	languagesLock.Lock()
+	defer languagesLock.Unlock()
+	for i, known := range languages {
+		if known.Code == code {
+			languages[i].Fallback = fallback
+			return
+		}
+	}
+	languages = append(languages, Language{Code: code, Name: code, NativeName: code, Fallback: fallback})
+}
+
+// FallbackChain lists the languages to look for a definition in, in order:
+// the language itself, its Fallback and English.
+func FallbackChain(code string) []string {
+	chain := []string{code}
+	if language, ok := LookupLanguage(code); ok {
+		for _, fallback := range language.Fallback {
+			if !slices.Contains(chain, fallback) {
+				chain = append(chain, fallback)
+			}
+		}
+	}
+	if !slices.Contains(chain, "en") {
+		chain = append(chain, "en")
+	}
+	return chain
+}
+
+// LanguageCodes lists the code of every configured language,
+// and then the other languages the definitions of the dictionary are in
+func (d *Dictionary) LanguageCodes() []string {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.languageCodes()
+}
+
+// LanguageCodes, d.lock must be held
+func (d *Dictionary) languageCodes() []string {
+	codes := LanguageCodes()
+	for _, code := range d.languages {
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// Note the languages of the word's definitions, d.languages stays sorted
+func (d *Dictionary) addLanguages(word Word) {
+	for code := range word.Definitions {
+		if i, found := slices.BinarySearch(d.languages, code); !found {
+			d.languages = slices.Insert(d.languages, i, code)
+		}
+	}
+}
+
+// definitionCodes lists the languages of all the definitions, the configured ones in their order first
+func definitionCodes(definitions ...map[string]string) []string {
+	var codes []string
+	for _, code := range LanguageCodes() {
+		for _, d := range definitions {
+			if _, ok := d[code]; ok {
+				codes = append(codes, code)
+				break
+			}
+		}
+	}
+
+	var others []string
+	for _, d := range definitions {
+		for code := range d {
+			if !slices.Contains(codes, code) && !slices.Contains(others, code) {
+				others = append(others, code)
+			}
+		}
+	}
+	slices.Sort(others)
+
+	return append(codes, others...)
+}
+
+// isLanguageCode tells if a column of the dictionary file holds definitions: "de", "cs", "jbo", ...
+func isLanguageCode(column string) bool {
+	if len(column) < 2 || len(column) > 3 {
+		return false
+	}
+	for _, c := range column {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return column != "id" && column != "ipa"
+}
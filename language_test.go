@@ -0,0 +1,122 @@
+package fwew_lib
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLanguageFromDictionary(t *testing.T) {
+	keepLanguages(t)
+
+	row := testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person")
+	content := testDictHeader + "\tcs\tja\n" +
+		row + "\tčlověk\tNULL\n"
+	path := filepath.Join(t.TempDir(), dictFileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDictionary(NewFileSource(path))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the dictionary knows them
+	if language, ok := LookupLanguage("cs"); ok {
+		t.Errorf("LookupLanguage(cs) = %+v, %v", language, ok)
+	}
+	if codes := LanguageCodes(); len(codes) != 15 {
+		t.Errorf("LanguageCodes() = %v", codes)
+	}
+	codes := d.LanguageCodes()
+	if len(codes) != 17 || codes[15] != "cs" || codes[16] != "ja" {
+		t.Errorf("d.LanguageCodes() = %v", codes)
+	}
+
+	got := d.TranslateToNaviHash("člověk", "cs")
+	if len(got) != 1 || len(got[0]) != 2 || got[0][1].Navi != "tute" {
+		t.Fatalf("TranslateToNaviHash(cs) = %v", got)
+	}
+	// no Japanese definition, so it's the English one
+	if definition := got[0][1].Definition("ja"); definition != "person" {
+		t.Errorf("Definition(ja) = %q, want the English one", definition)
+	}
+	if line, _ := got[0][1].ToOutputLine("1", false, false, false, false, false, false, false, "cs"); !strings.HasSuffix(line, "n. člověk\n") {
+		t.Errorf("ToOutputLine(cs) = %q", line)
+	}
+	// an unknown language is English
+	if got := d.TranslateToNaviHash("person", "xx"); len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("TranslateToNaviHash(xx) = %v", got)
+	}
+
+	RegisterLanguage(Language{Code: "cs", Name: "Czech", NativeName: "Čeština"})
+	if language, _ := LookupLanguage("cs"); language.NativeName != "Čeština" {
+		t.Errorf("LookupLanguage(cs) after RegisterLanguage() = %+v", language)
+	}
+	if codes := LanguageCodes(); len(codes) != 16 || codes[15] != "cs" {
+		t.Errorf("LanguageCodes() after RegisterLanguage() = %v", codes)
+	}
+	if codes := d.LanguageCodes(); len(codes) != 17 || codes[15] != "cs" || codes[16] != "ja" {
+		t.Errorf("d.LanguageCodes() after RegisterLanguage() = %v", codes)
+	}
+}
+
+// A language of one dictionary doesn't show up in another one
+func TestLanguageOtherDictionary(t *testing.T) {
+	keepLanguages(t)
+
+	row := testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person")
+	path := filepath.Join(t.TempDir(), dictFileName)
+	if err := os.WriteFile(path, []byte(testDictHeader+"\tcs\n"+row+"\tčlověk\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stable := NewDictionary(NewMemorySource(testWords(row)))
+	if err := stable.Load(); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := json.Marshal(stable.SearchToNavi("person", "en"))
+	preview := NewDictionary(NewFileSource(path))
+	if err := preview.Load(); err != nil {
+		t.Fatal(err)
+	}
+	// the stable one loaded again after the preview
+	if err := stable.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	if codes := stable.LanguageCodes(); slices.Contains(codes, "cs") {
+		t.Errorf("stable LanguageCodes() = %v", codes)
+	}
+	if codes := preview.LanguageCodes(); !slices.Contains(codes, "cs") {
+		t.Errorf("preview LanguageCodes() = %v", codes)
+	}
+	if after, _ := json.Marshal(stable.SearchToNavi("person", "en")); string(after) != string(before) {
+		t.Errorf("stable SearchToNavi(person) = %s, was %s", after, before)
+	}
+	if word := stable.SearchToNavi("person", "en")[0].Matches[0].Word; word.Definition("cs") != "" {
+		t.Errorf("stable Definition(cs) = %q", word.Definition("cs"))
+	}
+	if size, _ := stable.GetDictSize("cs"); size != "1" {
+		t.Errorf("stable GetDictSize(cs) = %q", size)
+	}
+	if size, _ := preview.GetDictSize("cs"); size != "There are 1 entries in the dictionary." {
+		t.Errorf("preview GetDictSize(cs) = %q", size)
+	}
+}
+
+func TestWordSetDefinition(t *testing.T) {
+	word := testWords(testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"))[0]
+	other := word
+
+	word.SetDefinition("en", "human")
+	if word.Definition("en") != "human" || other.Definition("en") != "person" {
+		t.Errorf("SetDefinition() = %q, copy = %q", word.Definition("en"), other.Definition("en"))
+	}
+	if word.Equals(other) {
+		t.Error("Equals() after SetDefinition() = true")
+	}
+}
@@ -16,13 +16,12 @@
 package fwew_lib
 
 import (
+	"context"
 	"crypto/sha1"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
-	"path"
 	"strings"
 	"unicode"
 )
@@ -151,50 +150,18 @@ func StripChars(str, chr string) string {
 
 // DownloadDict downloads the latest released version of the dictionary file and saves it to the given filepath.
 // You can give an empty string as filepath param, to update the found dictionary file.
+// See Downloader for more control.
 func DownloadDict(filepath string) error {
-	var url = Text("dictURL")
-
-	// only try to find dictionary-file if no path is given
-	if filepath == "" {
-		filepath = FindDictionaryFile()
-	}
-
-	// if still no filepath is given, error out
-	if filepath == "" {
-		return DictionaryNotFound
-	}
-
-	// download the new dict
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
-	}
-
-	// create new file, this will remove the old file, if it exists
-	err = os.Mkdir(path.Dir(filepath), 0755)
-	out, err := os.Create(filepath)
-	if err != nil {
-		return err
-	}
-
-	// save downloaded dict to the opened file
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return err
-	}
-	err = out.Close()
-	if err != nil {
-		return err
-	}
-	err = resp.Body.Close()
-	if err != nil {
-		return err
-	}
-
-	// Update the hash in the version
-	Version.DictBuild = SHA1Hash(filepath)
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+	return DownloadDictContext(ctx, filepath)
+}
 
-	return nil
+// DownloadDictContext is DownloadDict, stopping when ctx is done.
+func DownloadDictContext(ctx context.Context, filepath string) error {
+	var downloader Downloader
+	_, err := downloader.Download(ctx, filepath)
+	return err
 }
 
 // GLOB https://github.com/ryanuber/go-glob
@@ -251,18 +218,33 @@ func Glob(pattern, subj string) bool {
 	return trailingGlob || strings.HasSuffix(subj, parts[end])
 }
 
-// SHA1Hash gets hash of dictionary file
+// SHA1Hash gets hash of dictionary file, or an empty string if it can't be read
 func SHA1Hash(filename string) string {
+	hash, err := fileHash(filename)
+	if err != nil {
+		log.Printf("Error hashing %s: %s", filename, err)
+		return ""
+	}
+	return hash
+}
+
+// the short sha1 of a file, as shown in Version.DictBuild
+func fileHash(filename string) (string, error) {
 	f, err := os.Open(filename)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
 	defer f.Close()
 	h := sha1.New()
 	if _, err := io.Copy(h, f); err != nil {
-		log.Fatal(err)
+		return "", err
 	}
-	return fmt.Sprintf("%x", h.Sum(nil))[0:8]
+	return fmt.Sprintf("%x", h.Sum(nil))[0:8], nil
+}
+
+// the short sha1 of some data, the same as fileHash of a file with this content
+func bytesHash(data []byte) string {
+	return fmt.Sprintf("%x", sha1.Sum(data))[0:8]
 }
 
 // compress compresses or normalizes each digraph of the given string to a unique single character
@@ -0,0 +1,305 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. lint.go checks the dictionary for mistakes.
+package fwew_lib
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ProblemKind tells what kind of mistake a Problem is
+type ProblemKind string
+
+const (
+	// A column of the dictionary file is missing or a line has too few fields
+	ProblemColumns ProblemKind = "columns"
+	// Two entries have the same ID
+	ProblemDuplicateID ProblemKind = "duplicate-id"
+	// Stressed is not a syllable of Syllables
+	ProblemStress ProblemKind = "stress"
+	// InfixLocations and InfixDots don't agree
+	ProblemInfixes ProblemKind = "infixes"
+	// The IPA doesn't romanize back to the Na'vi word
+	ProblemIPA ProblemKind = "ipa"
+	// The Na'vi word breaks the phonotactic rules
+	ProblemPhonotactics ProblemKind = "phonotactics"
+	// A definition is missing
+	ProblemMissingTranslation ProblemKind = "missing-translation"
+)
+
+// Problem is one mistake found in the dictionary
+type Problem struct {
+	Kind ProblemKind
+	// The entry, empty for problems of the whole file
+	ID   string
+	Navi string
+	// The Word field or column with the problem, e.g. "Stressed", or the language code of a definition
+	Field string
+	// Line in the dictionary file, 0 if not known
+	Line    int
+	Message string
+}
+
+func (p Problem) String() string {
+	where := ""
+	if p.Line > 0 {
+		where = fmt.Sprintf("line %d: ", p.Line)
+	}
+	if p.ID != "" {
+		where += fmt.Sprintf("%s (%s): ", p.ID, p.Navi)
+	}
+	return fmt.Sprintf("%s[%s] %s", where, p.Kind, p.Message)
+}
+
+// LintOptions are the known exceptions for the linter
+type LintOptions struct {
+	// Na'vi words that are allowed to break the phonotactic rules, like Jakesully or Oìsss
+	AllowedOddballs []string
+	// Na'vi words whose IPA is allowed to not match, like zenke
+	AllowedIPA []string
+	// Languages to check for missing definitions, nil means all
+	Languages []string
+}
+
+// LintDictionary reads the source and checks every entry, see LintWords.
+// For dictionary files, the header and the number of fields in every line are checked, too.
+func LintDictionary(source DictionarySource, options LintOptions) (problems []Problem, err error) {
+	var words []Word
+
+	switch s := source.(type) {
+	case *FileSource:
+		if fallback := s.fallback(); fallback != nil {
+			problems, words, err = lintData(bytes.NewReader(fallback.Data))
+			break
+		}
+		var path string
+		path, err = s.file()
+		if err != nil {
+			return
+		}
+		var file *os.File
+		file, err = os.Open(path)
+		if err != nil {
+			return
+		}
+		defer file.Close()
+		problems, words, err = lintData(file)
+	case *EmbeddedSource:
+		problems, words, err = lintData(bytes.NewReader(s.Data))
+	default:
+		words, err = sourceWords(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return append(problems, LintWords(words, options)...), nil
+}
+
+// Read the dictionary file like readDictionary, but report broken lines instead of failing on them
+func lintData(r io.Reader) (problems []Problem, words []Word, err error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return nil, nil, scanner.Err()
+	}
+
+	header := strings.Split(scanner.Text(), "\t")
+	for _, column := range missingColumns(header) {
+		problems = append(problems, Problem{
+			Kind:    ProblemColumns,
+			Field:   column,
+			Line:    1,
+			Message: fmt.Sprintf("the header has no %s column", column),
+		})
+	}
+	for _, code := range LanguageCodes() {
+		if !ContainsStr(header, code) && !ContainsStr(dictColumns, code) {
+			problems = append(problems, Problem{
+				Kind:    ProblemColumns,
+				Field:   code,
+				Line:    1,
+				Message: fmt.Sprintf("the header has no %s column", code),
+			})
+		}
+	}
+	pos := readDictPos(header)
+
+	line := 1
+	for scanner.Scan() {
+		line++
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < len(header) {
+			problems = append(problems, Problem{
+				Kind:    ProblemColumns,
+				Line:    line,
+				Message: fmt.Sprintf("%d fields instead of %d", len(fields), len(header)),
+			})
+			continue
+		}
+		words = append(words, newWord(fields, pos))
+	}
+
+	return problems, words, scanner.Err()
+}
+
+// LintWords checks every entry for mistakes:
+// duplicate IDs, Stressed outside of Syllables, InfixLocations and InfixDots not agreeing,
+// IPA that doesn't romanize back to Navi, words breaking the phonotactic rules and missing definitions.
+func LintWords(words []Word, options LintOptions) (problems []Problem) {
+	allowedOddballs := lintSet(options.AllowedOddballs)
+	allowedIPA := lintSet(options.AllowedIPA)
+
+	languages := options.Languages
+	if languages == nil {
+		languages = LanguageCodes()
+	}
+
+	seen := map[string]bool{}
+	for _, word := range words {
+		add := func(kind ProblemKind, field string, format string, a ...any) {
+			problems = append(problems, Problem{
+				Kind:    kind,
+				ID:      word.ID,
+				Navi:    word.Navi,
+				Field:   field,
+				Message: fmt.Sprintf(format, a...),
+			})
+		}
+
+		if seen[word.ID] {
+			add(ProblemDuplicateID, "ID", "ID %s is used more than once", word.ID)
+		}
+		seen[word.ID] = true
+
+		if message := lintStress(word); message != "" {
+			add(ProblemStress, "Stressed", "%s", message)
+		}
+
+		if message := lintInfixes(word); message != "" {
+			add(ProblemInfixes, "InfixDots", "%s", message)
+		}
+
+		if !allowedIPA[lintKey(word.Navi)] {
+			if message := lintIPA(word); message != "" {
+				add(ProblemIPA, "IPA", "%s", message)
+			}
+		}
+
+		if !allowedOddballs[lintKey(word.Navi)] && isOddball(word.Navi) {
+			add(ProblemPhonotactics, "Navi", "%s breaks the phonotactic rules", word.Navi)
+		}
+
+		for _, code := range languages {
+			if NullDef(word.Definitions[code]) {
+				add(ProblemMissingTranslation, code, "no %s definition", code)
+			}
+		}
+	}
+
+	return
+}
+
+func lintKey(navi string) string {
+	return strings.ToLower(strings.ReplaceAll(navi, "’", "'"))
+}
+
+func lintSet(navi []string) map[string]bool {
+	set := make(map[string]bool, len(navi))
+	for _, a := range navi {
+		set[lintKey(a)] = true
+	}
+	return set
+}
+
+func lintStress(word Word) string {
+	stressed, err := strconv.Atoi(word.Stressed)
+	if err != nil {
+		return fmt.Sprintf("stress %q is not a number", word.Stressed)
+	}
+	syllables := len(strings.FieldsFunc(word.Syllables, func(r rune) bool {
+		return r == '-' || r == ' '
+	}))
+	if stressed < 1 || stressed > syllables {
+		return fmt.Sprintf("stress %d, but %q has %d syllables", stressed, word.Syllables, syllables)
+	}
+	return ""
+}
+
+func lintInfixes(word Word) string {
+	locationsNull := NullDef(word.InfixLocations)
+	dotsNull := NullDef(word.InfixDots)
+	if locationsNull && dotsNull {
+		return ""
+	}
+	if locationsNull != dotsNull {
+		return fmt.Sprintf("infixes %q, but infix dots %q", word.InfixLocations, word.InfixDots)
+	}
+
+	// '<0><1>amp<2>i is '.amp.i
+	dots := strings.ReplaceAll(word.InfixLocations, "<0>", "")
+	dots = strings.ReplaceAll(dots, "<1>", ".")
+	dots = strings.ReplaceAll(dots, "<2>", ".")
+	if dots != word.InfixDots {
+		return fmt.Sprintf("infixes %q mean infix dots %q, not %q", word.InfixLocations, dots, word.InfixDots)
+	}
+	return ""
+}
+
+func lintIPA(word Word) string {
+	navi := lintCompare(word.Navi)
+	var romanized []string
+	for _, ipa := range strings.Split(word.IPA, " or ") {
+		ipa = strings.Trim(ipa, "[] ")
+		var ipaWords []string
+		for _, ipaWord := range strings.Split(ipa, " ") {
+			romanized, ok := romanizeIPAWord(ipaWord)
+			if !ok {
+				return fmt.Sprintf("IPA %q can't be romanized", word.IPA)
+			}
+			ipaWords = append(ipaWords, romanized)
+		}
+		romanization := strings.Join(ipaWords, " ")
+		if lintCompare(romanization) == navi {
+			return ""
+		}
+		romanized = append(romanized, romanization)
+	}
+	return fmt.Sprintf("IPA %q romanizes to %q", word.IPA, strings.Join(romanized, " or "))
+}
+
+// Only the letters matter, not capitals, punctuation or the reef ù
+func lintCompare(navi string) string {
+	navi = lintKey(navi)
+	navi = strings.ReplaceAll(navi, "ù", "u")
+	navi = strings.ReplaceAll(navi, "é", "e")
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || r == '\'' || r == ' ' {
+			return r
+		}
+		return -1
+	}, navi)
+}
+
+// Lint checks the dictionary's source for mistakes, see LintDictionary.
+func (d *Dictionary) Lint(options LintOptions) ([]Problem, error) {
+	return LintDictionary(d.Source(), options)
+}
@@ -0,0 +1,98 @@
+package fwew_lib
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintWords(t *testing.T) {
+	words := testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈt'ɪ", "NULL", "intj.", "3", "kal-txì", "NULL", "hello"),
+		testDictRow("3", "taron", "ˈta.ɾon", "t<0><1>ar<2>on", "vtr.", "1", "ta-ron", "t.ar.on", "hunt"),
+		testDictRow("4", "tìng", "ˈtɪŋ", "t<0><1>ì<2>ng", "vtr.", "1", "tìng", "NULL", "give"),
+		testDictRow("5", "tsmukan", "ˈt͡smu.kɛ", "NULL", "n.", "1", "tsmu-kan", "NULL", "brother"),
+		testDictRow("1", "Jakesully", "ˈd͡ʒɛjk.sʊ.li", "NULL", "pn.", "1", "Jake-sul-ly", "NULL", "Jake Sully"),
+		testDictRow("6", "'rrta", "ˈʔr\u0323.ta", "NULL", "n.", "1", "'rr-ta", "NULL", "Earth"),
+		// a syllable without a vowel, Load must not choke on it either
+		testDictRow("7", "tsme", "[ˈt͡s.mɛ] or [ˈt͡s.mɛ]", "NULL", "n.", "1", "ts-me", "NULL", "thing"),
+	)
+	words[0].SetDefinition("fr", "NULL")
+
+	problems := LintWords(words, LintOptions{AllowedIPA: []string{"jakesully"}, Languages: []string{"en", "fr"}})
+
+	type found struct {
+		kind  ProblemKind
+		id    string
+		field string
+	}
+	var got []found
+	for _, problem := range problems {
+		got = append(got, found{problem.Kind, problem.ID, problem.Field})
+		if problem.Message == "" || !strings.Contains(problem.String(), problem.ID) {
+			t.Errorf("problem without a message: %+v", problem)
+		}
+	}
+	want := []found{
+		{ProblemMissingTranslation, "1", "fr"},
+		{ProblemStress, "2", "Stressed"},
+		{ProblemInfixes, "4", "InfixDots"},
+		{ProblemIPA, "5", "IPA"},
+		{ProblemDuplicateID, "1", "ID"},
+		{ProblemPhonotactics, "1", "Navi"},
+		{ProblemIPA, "7", "IPA"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LintWords() =\n%v\nwant\n%v", problems, want)
+	}
+
+	// the same words as oddballs of the hash
+	d := NewDictionary(NewMemorySource(words))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if d.oddballs != "Jakesully " {
+		t.Errorf("oddballs = %q, want Jakesully", d.oddballs)
+	}
+
+	problems = LintWords(words[5:6], LintOptions{AllowedOddballs: []string{"Jakesully"}, AllowedIPA: []string{"Jakesully"}})
+	if len(problems) != 0 {
+		t.Errorf("LintWords() with allowed words = %v", problems)
+	}
+}
+
+func TestLintDictionary(t *testing.T) {
+	header := strings.Replace(testDictHeader, "\tsv", "", 1)
+	row := testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person")
+	row = row[:strings.LastIndex(row, "\t")]
+	content := header + "\n" + row + "\n1\ttute\n"
+	path := filepath.Join(t.TempDir(), dictFileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := NewDictionary(NewFileSource(path)).Lint(LintOptions{})
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(problems) != 3 ||
+		problems[0].Kind != ProblemColumns || problems[0].Field != "sv" || problems[0].Line != 1 ||
+		problems[1].Kind != ProblemColumns || problems[1].Line != 3 ||
+		problems[2].Kind != ProblemMissingTranslation || problems[2].Field != "sv" {
+		t.Errorf("Lint() = %v", problems)
+	}
+
+	// other sources only check the entries
+	words := testWords(testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "3", "tu-te", "NULL", "person"))
+	problems, err = LintDictionary(NewMemorySource(words), LintOptions{})
+	if err != nil || len(problems) != 1 || problems[0].Kind != ProblemStress {
+		t.Errorf("LintDictionary() = %v, %v", problems, err)
+	}
+
+	if _, err := LintDictionary(NewFileSource(filepath.Join(t.TempDir(), "missing.txt")), LintOptions{}); !os.IsNotExist(err) {
+		t.Errorf("LintDictionary() of a missing file error = %v", err)
+	}
+}
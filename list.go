@@ -12,10 +12,10 @@ import (
 // args can be empty, if so, the whole Dict will be returned (This also happens if < 3 args are given)
 // It will try to always get 3 args and an `and` in between. If less than 3 exist, than it will wil return the previous
 // results.
-func List(args []string, checkDigraphs uint8) (results []Word, err error) {
-	universalLock.Lock()
-	defer universalLock.Unlock()
-	results, err = GetFullDict()
+func (d *Dictionary) List(args []string, checkDigraphs uint8) (results []Word, err error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	results, err = d.fullDict()
 
 	if err != nil {
 		return
@@ -72,15 +72,15 @@ func listWords(args []string, words []Word, checkDigraphs uint8) (results []Word
 }
 
 // Return a complete sentence
-func ListHelp(lang string) (count string, err error) {
-	universalLock.Lock()
-	defer universalLock.Unlock()
+func (d *Dictionary) ListHelp(lang string) (count string, err error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	// Count words
 	amount := 0
-	if dictionaryCached {
-		amount = len(dictionary)
+	if d.wordsCached {
+		amount = len(d.words)
 	} else {
-		err = runOnFile(func(word Word) error {
+		err = d.source.Run(func(word Word) error {
 			amount++
 			return nil
 		})
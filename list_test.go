@@ -484,30 +484,32 @@ func TestList(t *testing.T) {
 						Prefix:   nil,
 						Suffix:   nil,
 					},
-					ID:             "12",
-					DE:             "eins",
-					EN:             "one",
-					ES:             "uno",
-					ET:             "üks",
-					FR:             "1 (un)",
-					HU:             "egy, 1",
-					IT:             "uno",
+					ID: "12",
+					Definitions: map[string]string{
+						"de": "eins",
+						"en": "one",
+						"es": "uno",
+						"et": "üks",
+						"fr": "1 (un)",
+						"hu": "egy, 1",
+						"it": "uno",
+						"ko": "1, 하나",
+						"nl": "één",
+						"pl": "jeden",
+						"pt": "um",
+						"ru": "один (число)",
+						"sv": "en, ett",
+						"tr": "bir",
+						"uk": "один",
+					},
 					IPA:            "ʔaw",
 					InfixDots:      "NULL",
 					InfixLocations: "NULL",
-					KO:             "1, 하나",
-					NL:             "één",
 					Navi:           "'aw",
-					PL:             "jeden",
-					PT:             "um",
 					PartOfSpeech:   "num.",
-					RU:             "один (число)",
-					SV:             "en, ett",
 					Source:         "https://forum.learnnavi.org/?msg=67090 (2010-01-30)",
 					Stressed:       "1",
 					Syllables:      "'aw",
-					TR:             "bir",
-					UK:             "один",
 				},
 			},
 			wantErr: nil,
@@ -0,0 +1,43 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// getdict downloads the dictionary file for the fwew_embed build tag, see embedded_dictionary.go.
+//
+//	go run ./misc/getdict [path]
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	fwew "github.com/fwew/fwew-lib/v5"
+)
+
+func main() {
+	path := "dictionary-v2.txt"
+	if len(os.Args) > 1 {
+		path = os.Args[1]
+	}
+
+	var downloader fwew.Downloader
+	updated, err := downloader.Download(context.Background(), path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "getdict:", err)
+		os.Exit(1)
+	}
+	if !updated {
+		fmt.Println(path, "is up-to-date")
+	}
+}
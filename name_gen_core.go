@@ -95,10 +95,6 @@ var valid_triple_consonants = map[string]map[string]map[string]int{
 	},
 }
 
-var multiword_words = map[string][][]string{}
-var multiword_words_loose = map[string][][]string{}
-var multiword_words_reef = map[string][][]string{}
-
 /* Calculated on startup to assist the random number generators and letter selector */
 var max_onset = 0
 var max_non_cluster = 0
@@ -595,14 +591,28 @@ func PhonemeDistros() {
 	// get the dict
 	words, err := List([]string{}, 0)
 
-	clear(multiword_words)
-	clear(multiword_words_loose)
-	clear(multiword_words_reef)
+	// Piggybacking off of the frequency script to get all words with spaces
+	defaultDictionary.lock.Lock()
+	defaultDictionary.cacheMultiwords(words)
+	defaultDictionary.lock.Unlock()
 
 	if err != nil || len(words) == 0 {
 		return
 	}
 
+	resetPhonemeDistros()
+
+	// Look through all the words
+	for i := 0; i < len(words); i++ {
+		countPhonemes(words[i])
+	}
+
+	finishPhonemeDistros()
+}
+
+// Set the phoneme maps to zero before counting.
+// phonoLock must be held.
+func resetPhonemeDistros() {
 	//set the maps to zero
 
 	//Onsets
@@ -629,277 +639,205 @@ func PhonemeDistros() {
 	for i := 0; i < len(coda_likelihood); i++ {
 		coda_map[coda_letters[i]] = 0
 	}
+}
 
+// Count the phonemes of one word into the phoneme maps.
+// phonoLock must be held.
+func countPhonemes(dictWord Word) {
 	//syllable_map := map[string]int{}
+	word := strings.Split(dictWord.IPA, " ")
 
-	// Look through all the words
-	for i := 0; i < len(words); i++ {
-		word := strings.Split(words[i].IPA, " ")
-
-		// Piggybacking off of the frequency script to get all words with spaces
-		all_words := strings.Split(strings.ToLower(words[i].Navi), " ")
-		if len(all_words) > 1 {
-			new_words := dialectCrunch(all_words, true, true, false)
-			new_words_reef := dialectCrunch(all_words, true, true, true)
-			if _, ok := multiword_words_loose[new_words[0]]; ok {
-				// Ensure no duplicates
-				appended := false
-
-				// Append in a way that makes the longer words first
-				temp := [][]string{}
-				for _, j := range multiword_words_loose[new_words[0]] {
-					if !appended && len([]rune(new_words[1])) > len([]rune(j[0])) {
-						temp = append(temp, new_words[1:])
-						appended = true
-					}
-					temp = append(temp, j)
-				}
-				if len(temp) <= len(multiword_words_loose[new_words[0]]) {
-					temp = append(temp, new_words[1:])
-				}
-
-				multiword_words_loose[new_words[0]] = temp
-			} else {
-				multiword_words_loose[new_words[0]] = [][]string{new_words[1:]}
-			}
-
-			if _, ok := multiword_words_reef[new_words_reef[0]]; ok {
-				// Ensure no duplicates
-				appended := false
-
-				// Append in a way that makes the longer words first
-				temp := [][]string{}
-				for _, j := range multiword_words_reef[new_words_reef[0]] {
-					if !appended && len([]rune(new_words_reef[1])) > len([]rune(j[0])) {
-						temp = append(temp, new_words_reef[1:])
-						appended = true
-					}
-					temp = append(temp, j)
-				}
-				if len(temp) <= len(multiword_words_reef[new_words_reef[0]]) {
-					temp = append(temp, new_words_reef[1:])
-				}
-
-				multiword_words_reef[new_words_reef[0]] = temp
-			} else {
-				multiword_words_reef[new_words_reef[0]] = [][]string{new_words_reef[1:]}
-			}
-
-			if _, ok := multiword_words[all_words[0]]; ok {
-				// Ensure no duplicates
-				appended := false
-
-				// Append in a way that makes the longer words first
-				temp := [][]string{}
-				for _, j := range multiword_words[all_words[0]] {
-					if !appended && len([]rune(all_words[1])) > len([]rune(j[0])) {
-						temp = append(temp, all_words[1:])
-						appended = true
-					}
-					temp = append(temp, j)
-				}
-				if len(temp) <= len(multiword_words[all_words[0]]) {
-					temp = append(temp, all_words[1:])
-				}
-
-				multiword_words[all_words[0]] = temp
-			} else {
-				multiword_words[all_words[0]] = [][]string{all_words[1:]}
-			}
+	for j := 0; j < len(word); j++ {
+		word[j] = strings.Replace(word[j], "]", "", 1500)
+		// "or" means there's more than one IPA in this word, and we only want one
+		if word[j] == "or" {
+			break
 		}
 
-		for j := 0; j < len(word); j++ {
-			word[j] = strings.Replace(word[j], "]", "", 1500)
-			// "or" means there's more than one IPA in this word, and we only want one
-			if word[j] == "or" {
-				break
-			}
-
-			syllables := strings.Split(word[j], ".")
-			coda := ""
-
-			/* Onset */
-			for k := 0; k < len(syllables); k++ {
-				syllable := strings.Replace(syllables[k], "·", "", 1500)
-				syllable = strings.Replace(syllable, "ˈ", "", 1500)
-				syllable = strings.Replace(syllable, "ˌ", "", 1500)
-
-				onset_if_cluster := [2]string{"", ""}
-
-				//roman_syllable := ""
-
-				// ts
-				if len(syllable) >= 4 && syllable[0:4] == "t͡s" {
-					onset_if_cluster[0] = "ts"
-					//tsp
-					if hasAt("ptk", syllable, 3) {
-						if nth_rune(syllable, 4) == "'" {
-							// ts + ejective onset
-							cluster_map["ts"][romanization[syllable[4:6]]] = cluster_map["ts"][romanization[syllable[4:6]]] + 1
-							onset_if_cluster[1] = romanization[syllable[4:6]]
-							//roman_syllable += "ts" + romanization[syllable[4:6]]
-							syllable = syllable[6:]
-						} else {
-							// ts + unvoiced plosive
-							cluster_map["ts"][romanization[string(syllable[4])]] = cluster_map["ts"][romanization[string(syllable[4])]] + 1
-							onset_if_cluster[1] = romanization[string(syllable[4])]
-							//roman_syllable += "ts" + romanization[string(syllable[4])]
-							syllable = syllable[5:]
-						}
-					} else if hasAt("lɾmnŋwj", syllable, 3) {
-						// ts + other consonent
-						cluster_map["ts"][romanization[nth_rune(syllable, 3)]] = cluster_map["ts"][romanization[nth_rune(syllable, 3)]] + 1
-						onset_if_cluster[1] = romanization[nth_rune(syllable, 3)]
-						//roman_syllable += "ts" + romanization[nth_rune(syllable, 3)]
-						syllable = syllable[4+len(nth_rune(syllable, 3)):]
+		syllables := strings.Split(word[j], ".")
+		coda := ""
+
+		/* Onset */
+		for k := 0; k < len(syllables); k++ {
+			syllable := strings.Replace(syllables[k], "·", "", 1500)
+			syllable = strings.Replace(syllable, "ˈ", "", 1500)
+			syllable = strings.Replace(syllable, "ˌ", "", 1500)
+
+			onset_if_cluster := [2]string{"", ""}
+
+			//roman_syllable := ""
+
+			// ts
+			if len(syllable) >= 4 && syllable[0:4] == "t͡s" {
+				onset_if_cluster[0] = "ts"
+				//tsp
+				if hasAt("ptk", syllable, 3) {
+					if nth_rune(syllable, 4) == "'" {
+						// ts + ejective onset
+						cluster_map["ts"][romanization[syllable[4:6]]] = cluster_map["ts"][romanization[syllable[4:6]]] + 1
+						onset_if_cluster[1] = romanization[syllable[4:6]]
+						//roman_syllable += "ts" + romanization[syllable[4:6]]
+						syllable = syllable[6:]
 					} else {
-						// ts without a cluster
-						onset_map["ts"] = onset_map["ts"] + 1
-						//roman_syllable += "ts"
-						syllable = syllable[4:]
+						// ts + unvoiced plosive
+						cluster_map["ts"][romanization[string(syllable[4])]] = cluster_map["ts"][romanization[string(syllable[4])]] + 1
+						onset_if_cluster[1] = romanization[string(syllable[4])]
+						//roman_syllable += "ts" + romanization[string(syllable[4])]
+						syllable = syllable[5:]
 					}
-				} else if hasAt("fs", syllable, 0) {
-					//
-					onset_if_cluster[0] = string(syllable[0])
-					if hasAt("ptk", syllable, 1) {
-						if nth_rune(syllable, 2) == "'" {
-							// f/s + ejective onset
-							cluster_map[string(syllable[0])][romanization[syllable[1:3]]] = cluster_map[string(syllable[0])][romanization[syllable[1:3]]] + 1
-							onset_if_cluster[1] = romanization[syllable[1:3]]
-							//roman_syllable += string(syllable[0]) + romanization[syllable[1:3]]
-							syllable = syllable[3:]
-						} else {
-							// f/s + unvoiced plosive
-							cluster_map[string(syllable[0])][romanization[string(syllable[1])]] = cluster_map[string(syllable[0])][romanization[string(syllable[1])]] + 1
-							onset_if_cluster[1] = romanization[string(syllable[1])]
-							//roman_syllable += string(syllable[0]) + romanization[string(syllable[1])]
-							syllable = syllable[2:]
-						}
-					} else if hasAt("lɾmnŋwj", syllable, 1) {
-						// f/s + other consonent
-						cluster_map[string(syllable[0])][romanization[nth_rune(syllable, 1)]] = cluster_map[string(syllable[0])][romanization[nth_rune(syllable, 1)]] + 1
-						onset_if_cluster[1] = romanization[nth_rune(syllable, 1)]
-						//roman_syllable += string(syllable[0]) + romanization[nth_rune(syllable, 1)]
-						syllable = syllable[1+len(nth_rune(syllable, 1)):]
+				} else if hasAt("lɾmnŋwj", syllable, 3) {
+					// ts + other consonent
+					cluster_map["ts"][romanization[nth_rune(syllable, 3)]] = cluster_map["ts"][romanization[nth_rune(syllable, 3)]] + 1
+					onset_if_cluster[1] = romanization[nth_rune(syllable, 3)]
+					//roman_syllable += "ts" + romanization[nth_rune(syllable, 3)]
+					syllable = syllable[4+len(nth_rune(syllable, 3)):]
+				} else {
+					// ts without a cluster
+					onset_map["ts"] = onset_map["ts"] + 1
+					//roman_syllable += "ts"
+					syllable = syllable[4:]
+				}
+			} else if hasAt("fs", syllable, 0) {
+				//
+				onset_if_cluster[0] = string(syllable[0])
+				if hasAt("ptk", syllable, 1) {
+					if nth_rune(syllable, 2) == "'" {
+						// f/s + ejective onset
+						cluster_map[string(syllable[0])][romanization[syllable[1:3]]] = cluster_map[string(syllable[0])][romanization[syllable[1:3]]] + 1
+						onset_if_cluster[1] = romanization[syllable[1:3]]
+						//roman_syllable += string(syllable[0]) + romanization[syllable[1:3]]
+						syllable = syllable[3:]
 					} else {
-						// f/s without a cluster
-						onset_map[string(syllable[0])] = onset_map[string(syllable[0])] + 1
-						//roman_syllable += string(syllable[0])
-						syllable = syllable[1:]
-					}
-				} else if hasAt("ptk", syllable, 0) {
-					if nth_rune(syllable, 1) == "'" {
-						// ejective
-						onset_map[romanization[syllable[0:2]]] = onset_map[romanization[syllable[0:2]]] + 1
-						//roman_syllable += romanization[syllable[0:2]]
+						// f/s + unvoiced plosive
+						cluster_map[string(syllable[0])][romanization[string(syllable[1])]] = cluster_map[string(syllable[0])][romanization[string(syllable[1])]] + 1
+						onset_if_cluster[1] = romanization[string(syllable[1])]
+						//roman_syllable += string(syllable[0]) + romanization[string(syllable[1])]
 						syllable = syllable[2:]
-					} else {
-						// unvoiced plosive
-						onset_map[romanization[string(syllable[0])]] = onset_map[romanization[string(syllable[0])]] + 1
-						//roman_syllable += romanization[string(syllable[0])]
-						syllable = syllable[1:]
-					}
-				} else if hasAt("ʔlɾhmnŋvwjzbdg", syllable, 0) {
-					// other normal onset
-					onset_map[romanization[nth_rune(syllable, 0)]] = onset_map[romanization[nth_rune(syllable, 0)]] + 1
-					//roman_syllable += romanization[nth_rune(syllable, 0)]
-					syllable = syllable[len(nth_rune(syllable, 0)):]
-				} else if hasAt("ʃʒ", syllable, 0) {
-					// one sound representd as a cluster
-					if nth_rune(syllable, 0) == "ʃ" {
-						cluster_map["s"]["y"] = cluster_map["s"]["y"] + 1
-						//roman_syllable += "sy"
-					} else if nth_rune(syllable, 0) == "ʒ" {
-						cluster_map["ts"]["y"] = cluster_map["ts"]["y"] + 1
-						//roman_syllable += "tsy"
 					}
-					syllable = syllable[len(nth_rune(syllable, 0)):]
+				} else if hasAt("lɾmnŋwj", syllable, 1) {
+					// f/s + other consonent
+					cluster_map[string(syllable[0])][romanization[nth_rune(syllable, 1)]] = cluster_map[string(syllable[0])][romanization[nth_rune(syllable, 1)]] + 1
+					onset_if_cluster[1] = romanization[nth_rune(syllable, 1)]
+					//roman_syllable += string(syllable[0]) + romanization[nth_rune(syllable, 1)]
+					syllable = syllable[1+len(nth_rune(syllable, 1)):]
 				} else {
-					// no onset
-					onset_map[""] = onset_map[""] + 1
+					// f/s without a cluster
+					onset_map[string(syllable[0])] = onset_map[string(syllable[0])] + 1
+					//roman_syllable += string(syllable[0])
+					syllable = syllable[1:]
 				}
+			} else if hasAt("ptk", syllable, 0) {
+				if nth_rune(syllable, 1) == "'" {
+					// ejective
+					onset_map[romanization[syllable[0:2]]] = onset_map[romanization[syllable[0:2]]] + 1
+					//roman_syllable += romanization[syllable[0:2]]
+					syllable = syllable[2:]
+				} else {
+					// unvoiced plosive
+					onset_map[romanization[string(syllable[0])]] = onset_map[romanization[string(syllable[0])]] + 1
+					//roman_syllable += romanization[string(syllable[0])]
+					syllable = syllable[1:]
+				}
+			} else if hasAt("ʔlɾhmnŋvwjzbdg", syllable, 0) {
+				// other normal onset
+				onset_map[romanization[nth_rune(syllable, 0)]] = onset_map[romanization[nth_rune(syllable, 0)]] + 1
+				//roman_syllable += romanization[nth_rune(syllable, 0)]
+				syllable = syllable[len(nth_rune(syllable, 0)):]
+			} else if hasAt("ʃʒ", syllable, 0) {
+				// one sound representd as a cluster
+				if nth_rune(syllable, 0) == "ʃ" {
+					cluster_map["s"]["y"] = cluster_map["s"]["y"] + 1
+					//roman_syllable += "sy"
+				} else if nth_rune(syllable, 0) == "ʒ" {
+					cluster_map["ts"]["y"] = cluster_map["ts"]["y"] + 1
+					//roman_syllable += "tsy"
+				}
+				syllable = syllable[len(nth_rune(syllable, 0)):]
+			} else {
+				// no onset
+				onset_map[""] = onset_map[""] + 1
+			}
 
-				/* Found a triple consonant? */
-				if coda != "" && onset_if_cluster[1] != "" {
-					if val, ok := valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]]; ok {
-						valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]] = val + 1
-					} else if _, ok := valid_triple_consonants[coda][onset_if_cluster[0]]; ok {
-						valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]] = 1
-					} else if _, ok := valid_triple_consonants[coda]; ok {
-						valid_triple_consonants[coda][onset_if_cluster[0]] = make(map[string]int)
-						valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]] = 1
-					} else {
-						valid_triple_consonants[coda] = make(map[string]map[string]int)
-						valid_triple_consonants[coda][onset_if_cluster[0]] = make(map[string]int)
-						valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]] = 1
-					}
+			/* Found a triple consonant? */
+			if coda != "" && onset_if_cluster[1] != "" {
+				if val, ok := valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]]; ok {
+					valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]] = val + 1
+				} else if _, ok := valid_triple_consonants[coda][onset_if_cluster[0]]; ok {
+					valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]] = 1
+				} else if _, ok := valid_triple_consonants[coda]; ok {
+					valid_triple_consonants[coda][onset_if_cluster[0]] = make(map[string]int)
+					valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]] = 1
+				} else {
+					valid_triple_consonants[coda] = make(map[string]map[string]int)
+					valid_triple_consonants[coda][onset_if_cluster[0]] = make(map[string]int)
+					valid_triple_consonants[coda][onset_if_cluster[0]][onset_if_cluster[1]] = 1
 				}
-				//#    table_manager_supercluster(coda, start_cluster)
-				//#    coda = ""syllable[l
-				//#    start_cluster = ""
-
-				/*
-				 * Nucleus
-				 */
-				if len(syllable) > 1 && hasAt("jw", syllable, 1) {
-					//diphthong
-					nucleus_map[romanization[syllable[0:len(nth_rune(syllable, 0))+1]]] = nucleus_map[romanization[syllable[0:len(nth_rune(syllable, 0))+1]]] + 1
-					//roman_syllable += romanization[syllable[0:len(nth_rune(syllable, 0))+1]]
-					syllable = string([]rune(syllable)[2:])
-				} else if len(syllable) > 1 && hasAt("lr", syllable, 0) {
-					nucleus_map[romanization[syllable[0:3]]] = nucleus_map[romanization[syllable[0:3]]] + 1
-					//roman_syllable += romanization[syllable[0:3]]
-					continue
+			}
+			//#    table_manager_supercluster(coda, start_cluster)
+			//#    coda = ""syllable[l
+			//#    start_cluster = ""
+
+			/*
+			 * Nucleus
+			 */
+			if len(syllable) > 1 && hasAt("jw", syllable, 1) {
+				//diphthong
+				nucleus_map[romanization[syllable[0:len(nth_rune(syllable, 0))+1]]] = nucleus_map[romanization[syllable[0:len(nth_rune(syllable, 0))+1]]] + 1
+				//roman_syllable += romanization[syllable[0:len(nth_rune(syllable, 0))+1]]
+				syllable = string([]rune(syllable)[2:])
+			} else if len(syllable) > 1 && hasAt("lr", syllable, 0) {
+				nucleus_map[romanization[syllable[0:3]]] = nucleus_map[romanization[syllable[0:3]]] + 1
+				//roman_syllable += romanization[syllable[0:3]]
+				continue
+			} else {
+				//vowel
+				nucleus_map[romanization[nth_rune(syllable, 0)]] = nucleus_map[romanization[nth_rune(syllable, 0)]] + 1
+				//roman_syllable += romanization[nth_rune(syllable, 0)]
+				if len(syllable) == 0 {
+					fmt.Println("Invalid word: " + dictWord.ID + " - " + dictWord.Navi + " - " + dictWord.IPA)
 				} else {
-					//vowel
-					nucleus_map[romanization[nth_rune(syllable, 0)]] = nucleus_map[romanization[nth_rune(syllable, 0)]] + 1
-					//roman_syllable += romanization[nth_rune(syllable, 0)]
-					if len(syllable) == 0 {
-						fmt.Println("Invalid word: " + words[i].ID + " - " + words[i].Navi + " - " + words[i].IPA)
-					} else {
-						syllable = string([]rune(syllable)[1:])
-					}
+					syllable = string([]rune(syllable)[1:])
 				}
+			}
 
-				/*
-				 * Coda
-				 */
+			/*
+			 * Coda
+			 */
 
-				if len(syllable) == 0 || nth_rune(syllable, 0) == "s" {
-					coda_map[""] = coda_map[""] + 1 //oìsss only
-					coda = ""
+			if len(syllable) == 0 || nth_rune(syllable, 0) == "s" {
+				coda_map[""] = coda_map[""] + 1 //oìsss only
+				coda = ""
+			} else {
+				if syllable == "k̚" {
+					coda_map["k"] = coda_map["k"] + 1
+					coda = "k"
+				} else if syllable == "p̚" {
+					coda_map["p"] = coda_map["p"] + 1
+					coda = "p"
+				} else if syllable == "t̚" {
+					coda_map["t"] = coda_map["t"] + 1
+					coda = "t"
+				} else if syllable == "ʔ̚" {
+					coda_map["'"] = coda_map["'"] + 1
+					coda = "'"
 				} else {
-					if syllable == "k̚" {
-						coda_map["k"] = coda_map["k"] + 1
-						coda = "k"
-					} else if syllable == "p̚" {
-						coda_map["p"] = coda_map["p"] + 1
-						coda = "p"
-					} else if syllable == "t̚" {
-						coda_map["t"] = coda_map["t"] + 1
-						coda = "t"
-					} else if syllable == "ʔ̚" {
-						coda_map["'"] = coda_map["'"] + 1
-						coda = "'"
+					if syllable[0] == 'k' && len(syllable) > 1 {
+						coda_map["kx"] = coda_map["kx"] + 1
+						coda = "kx"
 					} else {
-						if syllable[0] == 'k' && len(syllable) > 1 {
-							coda_map["kx"] = coda_map["kx"] + 1
-							coda = "kx"
-						} else {
-							coda_map[romanization[syllable]] = coda_map[romanization[syllable]] + 1
-							coda = romanization[syllable]
-						}
+						coda_map[romanization[syllable]] = coda_map[romanization[syllable]] + 1
+						coda = romanization[syllable]
 					}
 				}
-				/*roman_syllable += coda
-
-				// Finally see if there is a good syllable frequency here
-				if _, ok := syllable_map[roman_syllable]; !ok {
-					syllable_map[roman_syllable] = 1
-				} else {
-					syllable_map[roman_syllable] = syllable_map[roman_syllable] + 1
-				}*/
 			}
+			/*roman_syllable += coda
+
+			// Finally see if there is a good syllable frequency here
+			if _, ok := syllable_map[roman_syllable]; !ok {
+				syllable_map[roman_syllable] = 1
+			} else {
+				syllable_map[roman_syllable] = syllable_map[roman_syllable] + 1
+			}*/
 		}
 	}
 
@@ -913,6 +851,14 @@ func PhonemeDistros() {
 	for _, a := range syllable_tuples {
 		fmt.Println(a)
 	}*/
+}
+
+// Copy the counted phoneme maps to the arrays the name generator uses.
+// phonoLock must be held.
+func finishPhonemeDistros() {
+	cluster_1 := []string{"f", "s", "ts"}
+	cluster_2 := []string{"k", "kx", "l", "m", "n", "ng", "p",
+		"px", "t", "tx", "r", "w", "y"}
 
 	max_non_cluster = 0
 	max_onset = 0
@@ -0,0 +1,158 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. normalize.go makes different forms of a natural language word the same.
+package fwew_lib
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// NatlangOptions change how the words of a natural language query are looked up
+type NatlangOptions struct {
+	// Ignore accents and find other forms of the word, like "run" for "running" or "Baum" for "Bäume".
+	// See Language.Normalize.
+	Normalize bool
+}
+
+// NormalizeTerm turns a word of a definition or query into the form used by NatlangOptions.Normalize.
+// Languages without a Normalize function are only folded to lower case.
+func NormalizeTerm(langCode string, term string) string {
+	return normalizer(langCode)(term)
+}
+
+// The Normalize function of the language, including the case folding
+func normalizer(langCode string) func(term string) string {
+	language, ok := LookupLanguage(langCode)
+	if !ok || language.Normalize == nil {
+		return strings.ToLower
+	}
+	return func(term string) string {
+		return language.Normalize(strings.ToLower(term))
+	}
+}
+
+// Normalize all the words of a definition, see SearchTerms
+func normalizeTerms(langCode string, terms []string) []string {
+	normalize := normalizer(langCode)
+	normalized := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term != "" {
+			normalized = append(normalized, normalize(term))
+		}
+	}
+	return normalized
+}
+
+// Like TranslateToNaviHashHelper, but with the normalized words of the definitions
+func (d *Dictionary) searchNormalized(searchWord string, langCode string) (results []Word) {
+	langCode = d.searchLanguage(langCode)
+	term := NormalizeTerm(langCode, searchWord)
+
+	for _, a := range d.SearchNatlangWord(d.hash2Normalized[langCode], term) {
+		// homonyms come along, make sure it is in this definition
+		if slices.Contains(normalizeTerms(langCode, SearchTerms(a.Definitions[langCode], false)), term) {
+			results = AppendAndAlphabetize(results, a)
+		}
+	}
+	return
+}
+
+// Letters with accents and the letters they fold to
+var accentFolding = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "ā", "a", "ą", "a",
+	"ç", "c", "ć", "c", "č", "c",
+	"è", "e", "é", "e", "ê", "e", "ë", "e", "ē", "e", "ę", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ī", "i", "ı", "i",
+	"ñ", "n", "ń", "n",
+	"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "ō", "o", "ő", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ū", "u", "ű", "u",
+	"ý", "y", "ÿ", "y",
+	"ß", "ss", "ł", "l", "ś", "s", "š", "s", "ş", "s", "ğ", "g", "ź", "z", "ż", "z", "ž", "z",
+	"œ", "oe", "æ", "ae",
+)
+
+// foldAccents makes "élève" and "eleve" the same
+func foldAccents(term string) string {
+	return accentFolding.Replace(term)
+}
+
+// foldCyrillic makes "ёж" and "еж" the same
+func foldCyrillic(term string) string {
+	return strings.ReplaceAll(term, "ё", "е")
+}
+
+// Strip the first suffix that fits, if at least minStem letters are left
+func stripSuffix(term string, minStem int, suffixes ...string) string {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(term, suffix) && utf8.RuneCountInString(term)-utf8.RuneCountInString(suffix) >= minStem {
+			return strings.TrimSuffix(term, suffix)
+		}
+	}
+	return term
+}
+
+// "runn" from "running" becomes "run"
+func undouble(term string) string {
+	n := len(term)
+	if n >= 2 && term[n-1] == term[n-2] && strings.IndexByte("bdfgkmnprt", term[n-1]) >= 0 {
+		return term[:n-1]
+	}
+	return term
+}
+
+// normalizeEnglish: running, runs -> run, hoped, hopes, hoping -> hop, flies -> fly
+func normalizeEnglish(term string) string {
+	term = foldAccents(term)
+	switch {
+	case strings.HasSuffix(term, "ies") && len(term) > 4:
+		term = strings.TrimSuffix(term, "ies") + "y"
+	case strings.HasSuffix(term, "sses"):
+		term = strings.TrimSuffix(term, "es")
+	case strings.HasSuffix(term, "ing") && len(term) > 5:
+		term = undouble(strings.TrimSuffix(term, "ing"))
+	case strings.HasSuffix(term, "ed") && len(term) > 4:
+		term = undouble(strings.TrimSuffix(term, "ed"))
+	case strings.HasSuffix(term, "s") && !strings.HasSuffix(term, "ss") && len(term) > 3:
+		term = strings.TrimSuffix(term, "s")
+	}
+	return stripSuffix(term, 3, "e")
+}
+
+// normalizeGerman: Bäume, Baum -> baum, Kindern -> kind
+func normalizeGerman(term string) string {
+	return stripSuffix(foldAccents(term), 3, "ern", "em", "en", "er", "es", "e", "n", "s")
+}
+
+// normalizeFrench: élèves, élève -> eleve
+func normalizeFrench(term string) string {
+	return stripSuffix(foldAccents(term), 3, "s", "x")
+}
+
+// normalizeSpanish also works for Portuguese: flores -> flor, casas -> casa
+func normalizeSpanish(term string) string {
+	return stripSuffix(foldAccents(term), 3, "es", "s")
+}
+
+// normalizeItalian: ragazzo, ragazzi -> ragazz
+func normalizeItalian(term string) string {
+	return stripSuffix(foldAccents(term), 4, "a", "e", "i", "o")
+}
+
+// normalizeDutch: bomen -> bom, huizen -> huiz, appels -> appel
+func normalizeDutch(term string) string {
+	return stripSuffix(foldAccents(term), 3, "en", "s")
+}
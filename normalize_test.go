@@ -0,0 +1,89 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTerm(t *testing.T) {
+	tests := []struct {
+		lang  string
+		terms []string
+	}{
+		{"en", []string{"run", "running", "runs"}},
+		{"en", []string{"hope", "hoped", "hopes", "hoping"}},
+		{"en", []string{"fly", "flies"}},
+		{"en", []string{"Café", "cafe"}},
+		{"de", []string{"Baum", "Bäume"}},
+		{"de", []string{"Kind", "Kinder", "Kindern"}},
+		{"fr", []string{"élève", "eleve", "élèves"}},
+		{"es", []string{"flor", "flores"}},
+		{"pt", []string{"casa", "casas"}},
+		{"it", []string{"ragazzo", "ragazzi"}},
+		{"nl", []string{"appel", "appels"}},
+		{"ru", []string{"ёж", "еж"}},
+		{"uk", []string{"ёж", "еж"}},
+		{"xx", []string{"word", "WORD"}},
+	}
+	for _, tt := range tests {
+		want := NormalizeTerm(tt.lang, tt.terms[0])
+		for _, term := range tt.terms[1:] {
+			if got := NormalizeTerm(tt.lang, term); got != want {
+				t.Errorf("NormalizeTerm(%s, %s) = %s, want %s like %s", tt.lang, term, got, want, tt.terms[0])
+			}
+		}
+	}
+
+	// different words stay different
+	for _, terms := range [][3]string{{"en", "be", "bee"}, {"en", "sing", "sin"}, {"de", "Bär", "Bier"}} {
+		if NormalizeTerm(terms[0], terms[1]) == NormalizeTerm(terms[0], terms[2]) {
+			t.Errorf("NormalizeTerm(%s) of %s and %s are the same", terms[0], terms[1], terms[2])
+		}
+	}
+}
+
+func TestSearchToNaviNormalized(t *testing.T) {
+	words := testWords(
+		testDictRow("1", "tul", "ˈtul", "t<0><1>u<2>l", "vin.", "1", "tul", "t.u.l", "run"),
+		testDictRow("2", "ioang", "i.ˈo.aŋ", "NULL", "n.", "2", "i-o-ang", "NULL", "animal, beast"),
+		testDictRow("3", "ngawng", "ˈŋawŋ", "NULL", "n.", "1", "ngawng", "NULL", "worm"),
+	)
+	words[0].SetDefinition("de", "rennen")
+	words[1].SetDefinition("de", "Tier, Bestie")
+	words[1].SetDefinition("fr", "animal, bête")
+	words[2].SetDefinition("fr", "ver (de terre)")
+	d := NewDictionary(NewMemorySource(words))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		lang  string
+		want  []string
+	}{
+		{"running", "en", []string{"tul"}},
+		{"Tiere", "de", []string{"ioang"}},
+		{"bete", "fr", []string{"ioang"}},
+		{"vers", "fr", []string{"ngawng"}},
+		{"runner", "en", nil},
+	}
+	for _, tt := range tests {
+		results := d.SearchToNaviWith(tt.query, tt.lang, NatlangOptions{Normalize: true})
+		if got := wordNavis(results[0].Words()); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SearchToNaviWith(%s, %s) = %v, want %v", tt.query, tt.lang, got, tt.want)
+		}
+
+		// exact search is still there
+		if exact := d.SearchToNavi(tt.query, tt.lang); len(exact[0].Matches) != 0 {
+			t.Errorf("SearchToNavi(%s, %s) = %v", tt.query, tt.lang, wordNavis(exact[0].Words()))
+		}
+	}
+
+	// the exact word is better than another form
+	exact := d.SearchToNaviWith("run", "en", NatlangOptions{Normalize: true})[0].Matches[0].Score
+	other := d.SearchToNaviWith("runs", "en", NatlangOptions{Normalize: true})[0].Matches[0].Score
+	if exact <= other {
+		t.Errorf("Score of run = %v, of runs = %v", exact, other)
+	}
+}
@@ -0,0 +1,202 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. paradigm.go makes the tables of every form of a word.
+package fwew_lib
+
+import (
+	"slices"
+	"strings"
+)
+
+// Number is the number of a noun, see NounParadigm
+type Number string
+
+const (
+	Singular Number = "singular"
+	Dual     Number = "dual"
+	Trial    Number = "trial"
+	Plural   Number = "plural"
+)
+
+// The prefix of every number
+var numberPrefixes = map[Number]string{Singular: "", Dual: "me", Trial: "pxe", Plural: "ay"}
+
+// The rows and columns of the tables, in order
+var (
+	paradigmNumbers = []Number{Singular, Dual, Trial, Plural}
+	paradigmCases   = []Case{Subjective, Agentive, Patientive, Dative, Genitive, Topical}
+)
+
+// ParadigmForm is one way to say a cell of a paradigm table
+type ParadigmForm struct {
+	Form string
+	// Not made by the rules, like tsal of tsaw or zenatseke of zenke
+	Irregular bool
+	// The reef dialect form, like tuteye for tuteyä
+	Reef bool
+	// A short plural without ay, like sute for aysute
+	Short bool
+}
+
+// NounCell is the forms of a noun in one number and case
+type NounCell struct {
+	Number Number
+	Case   Case
+	Forms  []ParadigmForm
+}
+
+// VerbCell is the forms of a verb with one infix in each position, or none
+type VerbCell struct {
+	// äp, eyk or äpeyk
+	Prefirst string
+	// tense, aspect, mood and participles, like ol or iv
+	First string
+	// attitude, like ei or äng
+	Second string
+	Forms  []ParadigmForm
+}
+
+// The reef forms of the affixes, the other way round than unreefFixes
+var reefFixes = map[string]string{}
+
+func init() {
+	for reef, forest := range unreefFixes {
+		reefFixes[forest] = reef
+	}
+}
+
+// NounParadigm makes the table of every number and case of a noun, singular first and subjective first in every number.
+// Pronouns only have the singular row, their other numbers are words of their own.
+// It fails with InvalidConjugation if the word isn't a noun or pronoun.
+func NounParadigm(word Word) ([]NounCell, error) {
+	numbers := paradigmNumbers
+	kind := kindOther
+	for _, pos := range strings.Split(word.PartOfSpeech, ",") {
+		pos = strings.TrimSpace(pos)
+		if partOfSpeechKind(pos) != kindNoun {
+			continue
+		}
+		kind = kindNoun
+		if pos == pn {
+			numbers = []Number{Singular}
+		}
+		break
+	}
+	if kind != kindNoun {
+		return nil, conjugationError("%s is not a noun", word.Navi)
+	}
+
+	navi := strings.ToLower(strings.ReplaceAll(word.Navi, "+", ""))
+	var cells []NounCell
+	for _, number := range numbers {
+		for _, c := range paradigmCases {
+			spec := ConjugationSpec{Case: c}
+			if prefix := numberPrefixes[number]; prefix != "" {
+				spec.Prefixes = []string{prefix}
+			}
+			forms, err := Conjugate(word, spec)
+			if err != nil {
+				continue
+			}
+
+			cell := NounCell{Number: number, Case: c}
+			_, irregular := irregularCaseForms[navi][c]
+			irregular = irregular || (c != Subjective && weirdStem(navi) != "")
+			for _, form := range forms {
+				short := number == Plural && !strings.HasPrefix(form, numberPrefixes[Plural])
+				cell.Forms = append(cell.Forms, ParadigmForm{Form: form, Irregular: irregular, Short: short})
+			}
+			// tuteye
+			if c == Genitive {
+				for _, form := range cell.Forms {
+					for _, ending := range []string{"yä", "ä"} {
+						if strings.HasSuffix(form.Form, ending) {
+							form.Form = strings.TrimSuffix(form.Form, ending) + reefFixes[ending]
+							form.Reef = true
+							cell.Forms = append(cell.Forms, form)
+							break
+						}
+					}
+				}
+			}
+			cells = append(cells, cell)
+		}
+	}
+	return cells, nil
+}
+
+// VerbParadigm makes the table of every combination of infixes a verb can have, the bare verb first.
+// The infixes are the forest ones of prefirst, first and second, the reef forms (ep, eng) are marked as Reef.
+// It fails with InvalidConjugation if the word is no verb or has no InfixLocations.
+func VerbParadigm(word Word) ([]VerbCell, error) {
+	if !slices.ContainsFunc(strings.Split(word.PartOfSpeech, ","), func(pos string) bool {
+		return partOfSpeechKind(strings.TrimSpace(pos)) == kindVerb
+	}) {
+		return nil, conjugationError("%s is not a verb", word.Navi)
+	}
+	if !hasInfixLocations(word) {
+		return nil, conjugationError("%s has no place for infixes", word.Navi)
+	}
+
+	var cells []VerbCell
+	for _, prefirstInfix := range paradigmInfixes(SlotPrefirst) {
+		for _, firstInfix := range paradigmInfixes(SlotFirst) {
+			for _, secondInfix := range paradigmInfixes(SlotSecond) {
+				infixList := slices.DeleteFunc([]string{prefirstInfix, firstInfix, secondInfix}, func(infix string) bool {
+					return infix == ""
+				})
+				forms, err := Conjugate(word, ConjugationSpec{Infixes: infixList})
+				if err != nil {
+					continue
+				}
+
+				cell := VerbCell{Prefirst: prefirstInfix, First: firstInfix, Second: secondInfix}
+				irregular := false
+				if locations, ok := irregularInfixLocations[word.InfixLocations]; ok {
+					irregular = slices.Contains(locations.second, secondInfix)
+				}
+				for _, form := range forms {
+					cell.Forms = append(cell.Forms, ParadigmForm{Form: form, Irregular: irregular})
+				}
+
+				// täparon and reef teparon
+				reefList := slices.Clone(infixList)
+				for i, infix := range reefList {
+					if reef, ok := reefFixes[infix]; ok {
+						reefList[i] = reef
+					}
+				}
+				if !slices.Equal(reefList, infixList) {
+					if form, err := placeInfixes(word, reefList); err == nil {
+						cell.Forms = append(cell.Forms, ParadigmForm{Form: form, Irregular: irregular, Reef: true})
+					}
+				}
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells, nil
+}
+
+// No infix and the forest infixes of one position, without their other spellings like iy for ìy or eiy for ei
+func paradigmInfixes(slot AffixSlot) []string {
+	infixList := []string{""}
+	for _, infix := range ListAffixes(InfixAffix, "", Productive) {
+		if infix.Slot == slot {
+			infixList = append(infixList, infix.Form)
+		}
+	}
+	return infixList
+}
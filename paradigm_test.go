@@ -0,0 +1,101 @@
+package fwew_lib
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNounParadigm(t *testing.T) {
+	words := conjugateTestWords()
+
+	cells, err := NounParadigm(words["tute"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != 24 || cells[0].Number != Singular || cells[0].Case != Subjective || cells[23].Number != Plural || cells[23].Case != Topical {
+		t.Fatalf("NounParadigm(tute) has %d cells", len(cells))
+	}
+	want := map[[2]string][]ParadigmForm{
+		{"singular", ""}:         {{Form: "tute"}},
+		{"dual", "agentive"}:     {{Form: "mesutel"}},
+		{"trial", "patientive"}:  {{Form: "pxesutet"}, {Form: "pxesuteti"}},
+		{"plural", ""}:           {{Form: "aysute"}, {Form: "sute", Short: true}},
+		{"singular", "genitive"}: {{Form: "tuteyä"}, {Form: "tuteye", Reef: true}},
+	}
+	for _, cell := range cells {
+		if forms, ok := want[[2]string{string(cell.Number), string(cell.Case)}]; ok && !reflect.DeepEqual(cell.Forms, forms) {
+			t.Errorf("NounParadigm(tute) %s %s = %+v, want %+v", cell.Number, cell.Case, cell.Forms, forms)
+		}
+	}
+
+	// pronouns have no numbers, and tsaw is irregular
+	cells, err = NounParadigm(words["tsaw"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != 6 {
+		t.Fatalf("NounParadigm(tsaw) has %d cells", len(cells))
+	}
+	if forms := cells[1].Forms; !reflect.DeepEqual(forms, []ParadigmForm{{Form: "tsal", Irregular: true}}) {
+		t.Errorf("NounParadigm(tsaw) agentive = %+v", forms)
+	}
+	if cells[0].Forms[0].Irregular {
+		t.Errorf("NounParadigm(tsaw) subjective = %+v", cells[0].Forms)
+	}
+
+	if _, err := NounParadigm(words["taron"]); !errors.Is(err, InvalidConjugation) {
+		t.Errorf("NounParadigm(taron) error = %v", err)
+	}
+}
+
+func TestVerbParadigm(t *testing.T) {
+	words := conjugateTestWords()
+
+	cells, err := VerbParadigm(words["taron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cells[0].Prefirst != "" || cells[0].First != "" || cells[0].Second != "" || cells[0].Forms[0].Form != "taron" {
+		t.Errorf("VerbParadigm(taron) starts with %+v", cells[0])
+	}
+
+	found := map[[3]string][]ParadigmForm{}
+	for _, cell := range cells {
+		found[[3]string{cell.Prefirst, cell.First, cell.Second}] = cell.Forms
+	}
+	want := map[[3]string][]ParadigmForm{
+		{"", "ol", ""}:         {{Form: "tolaron"}},
+		{"", "iv", "ei"}:       {{Form: "tivareion"}},
+		{"äp", "", ""}:         {{Form: "täparon"}, {Form: "teparon", Reef: true}},
+		{"äpeyk", "ìy", "äng"}: {{Form: "täpeykìyarängon"}, {Form: "tepeykìyarengon", Reef: true}},
+	}
+	for infixes, forms := range want {
+		if !reflect.DeepEqual(found[infixes], forms) {
+			t.Errorf("VerbParadigm(taron) %v = %+v, want %+v", infixes, found[infixes], forms)
+		}
+	}
+	// participles don't have attitudes, loose and reef spellings aren't infixes of their own
+	for _, infixes := range [][3]string{{"", "us", "ei"}, {"äp", "awn", ""}, {"", "iy", ""}, {"ep", "", ""}, {"", "", "eiy"}} {
+		if forms, ok := found[infixes]; ok {
+			t.Errorf("VerbParadigm(taron) %v = %+v", infixes, forms)
+		}
+	}
+
+	cells, err = VerbParadigm(words["zenke"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cell := range cells {
+		irregular := cell.Second == "ats" || cell.Second == "uy"
+		if cell.Forms[0].Irregular != irregular {
+			t.Errorf("VerbParadigm(zenke) %+v", cell)
+		}
+	}
+
+	for _, navi := range []string{"tute", "lor"} {
+		if _, err := VerbParadigm(words[navi]); !errors.Is(err, InvalidConjugation) {
+			t.Errorf("VerbParadigm(%s) error = %v", navi, err)
+		}
+	}
+}
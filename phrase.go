@@ -0,0 +1,171 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. phrase.go searches definitions for several words at once.
+package fwew_lib
+
+import (
+	"slices"
+	"strings"
+)
+
+// Where a word of the definitions is: language code -> word -> positions in every definition
+type positionDict map[string]map[string][]termPositions
+
+// The positions of a word in the definition of one entry
+type termPositions struct {
+	ID        string
+	Positions []int
+}
+
+// The words of the definition with their positions.
+// There is a gap at every comma or semicolon, so phrases don't go on across them.
+func definitionPositions(definition string) map[string][]int {
+	positions := map[string][]int{}
+	position := 0
+	for _, part := range strings.FieldsFunc(definition, func(r rune) bool {
+		return r == ',' || r == ';'
+	}) {
+		for _, term := range SearchTerms(part, false) {
+			if term == "" {
+				continue
+			}
+			positions[term] = append(positions[term], position)
+			position++
+		}
+		position++
+	}
+	return positions
+}
+
+// Split a query into its parts, every part is a quoted phrase or a single word.
+// `"to be" happy` has the parts [to be] and [happy].
+func parseDefinitionQuery(query string) (parts [][]string) {
+	for i, text := range strings.Split(query, `"`) {
+		terms := slices.DeleteFunc(SearchTerms(text, false), func(term string) bool {
+			return term == ""
+		})
+		if len(terms) == 0 {
+			continue
+		}
+		// every other piece is inside quotes
+		if i%2 == 1 {
+			parts = append(parts, terms)
+			continue
+		}
+		for _, term := range terms {
+			parts = append(parts, []string{term})
+		}
+	}
+	return
+}
+
+// SearchDefinitions finds the words whose definition in langCode has every part of the query.
+// A part is a single word or a "quoted phrase", whose words have to be next to each other in that order.
+// Definitions with all words of the query next to each other get a better Score.
+func (d *Dictionary) SearchDefinitions(query string, langCode string) (result SearchResult) {
+	parts := parseDefinitionQuery(query)
+	result.Query = strings.TrimSpace(query)
+
+	var terms []string
+	for _, part := range parts {
+		for _, term := range part {
+			if !slices.Contains(terms, term) {
+				terms = append(terms, term)
+			}
+		}
+	}
+	result.End = len(terms)
+	if len(terms) == 0 {
+		return
+	}
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	langCode = d.searchLanguage(langCode)
+
+	// the words found for every single term by the token maps
+	candidates := map[string]bool{}
+	for _, navi := range d.hash2Parenthesis[langCode][terms[0]] {
+		candidates[navi] = true
+	}
+	for _, term := range terms[1:] {
+		found := map[string]bool{}
+		for _, navi := range d.hash2Parenthesis[langCode][term] {
+			if candidates[navi] {
+				found[navi] = true
+			}
+		}
+		candidates = found
+	}
+
+	// term -> word ID -> positions
+	positions := map[string]map[string][]int{}
+	for _, term := range terms {
+		byID := map[string][]int{}
+		for _, p := range d.hash2Positions[langCode][term] {
+			byID[p.ID] = p.Positions
+		}
+		positions[term] = byID
+	}
+
+	// the whole query as one phrase
+	var all []string
+	for _, part := range parts {
+		all = append(all, part...)
+	}
+
+	words := []Word{}
+	for navi := range candidates {
+		for _, word := range d.hashStrict[navi] {
+			matches := true
+			for _, part := range parts {
+				if !phraseAt(positions, word.ID, part) {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				words = AppendAndAlphabetize(words, word)
+			}
+		}
+	}
+
+	for _, word := range words {
+		score := natlangScore(terms[0], word.Definitions[langCode], nil)
+		if len(all) > 1 && !phraseAt(positions, word.ID, all) {
+			score = max(score-scoreNotAdjacent, scoreMin)
+		}
+		result.Matches = append(result.Matches, Match{Word: word, Direction: ToNavi, Score: score})
+	}
+	return
+}
+
+// Are the words of phrase next to each other in the definition of the entry with the id?
+func phraseAt(positions map[string]map[string][]int, id string, phrase []string) bool {
+	for _, start := range positions[phrase[0]][id] {
+		found := true
+		for k, term := range phrase[1:] {
+			if !slices.Contains(positions[term][id], start+k+1) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchDefinitions(t *testing.T) {
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "lu", "ˈlu", "l<0><1><2>u", "vin.", "1", "lu", "l..u", "be, have"),
+		testDictRow("2", "tsun", "ˈt͡sun", "ts<0><1><2>un", "vin.", "1", "tsun", "ts..un", "be able to, can"),
+		testDictRow("3", "new", "ˈnɛw", "n<0><1>ew", "vtr.", "1", "new", "n..ew", "want (to do something)"),
+		testDictRow("4", "tìng", "ˈtɪŋ", "t<0><1>ì<2>ng", "vtr.", "1", "tìng", "t.ì.ng", "give, be kind to"),
+		testDictRow("5", "tsap'alute", "t͡sa.ˈpʼa.lu.tɛ", "NULL", "n.", "3", "tsa-p'a-lu-te", "NULL", "apology, to be sorry"),
+		testDictRow("6", "fmi", "ˈfmi", "fm<0><1><2>i", "vtr.", "1", "fmi", "fm..i", "to, be trying"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`"be able"`, []string{"tsun"}},
+		{`"to be"`, []string{"tsap'alute"}},                      // not "to, be" across the comma
+		{`to be`, []string{"fmi", "tìng", "tsap'alute", "tsun"}}, // both somewhere in the definition
+		{`"able to" can`, []string{"tsun"}},
+		{`"to able"`, nil},             // the order matters
+		{`"want to"`, []string{"new"}}, // parentheses are part of the definition
+		{`to "be kind"`, []string{"tìng"}},
+		{`to unicorn`, nil},
+		{`""`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			result := d.SearchDefinitions(tt.query, "en")
+			var got []string
+			for _, match := range result.Matches {
+				got = append(got, match.Word.Navi)
+				if match.Direction != ToNavi {
+					t.Errorf("%s Direction = %s", match.Word.Navi, match.Direction)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchDefinitions(%s) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	// words next to each other are better
+	result := d.SearchDefinitions("to be", "en")
+	result.SortByScore()
+	if navi := wordNavis(result.Words()); navi[0] != "tsap'alute" || result.Matches[0].Score <= result.Matches[1].Score {
+		t.Errorf("SearchDefinitions(to be) by score = %v", navi)
+	}
+	if result.Query != "to be" || result.Start != 0 || result.End != 2 {
+		t.Errorf("SearchDefinitions(to be) = %q %d %d", result.Query, result.Start, result.End)
+	}
+
+	// unknown languages search English
+	if result := d.SearchDefinitions(`"be able"`, "xx"); len(result.Matches) != 1 {
+		t.Errorf("SearchDefinitions() in xx = %+v", result)
+	}
+}
+
+func TestDefinitionPositions(t *testing.T) {
+	got := definitionPositions("to be, to (have) done")
+	want := map[string][]int{"to": {0, 3}, "be": {1}, "have": {4}, "done": {5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("definitionPositions() = %v, want %v", got, want)
+	}
+}
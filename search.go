@@ -0,0 +1,201 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. search.go holds the structured search results.
+package fwew_lib
+
+import (
+	"sort"
+	"strings"
+)
+
+// Direction tells which way a Match was found
+type Direction string
+
+const (
+	// The query was Na'vi
+	FromNavi Direction = "navi"
+	// The query was a word of a definition
+	ToNavi Direction = "natlang"
+	// The query was a pronunciation, see SearchIPA
+	FromIPA Direction = "ipa"
+)
+
+// Match is one word a part of the query can be
+type Match struct {
+	// The word, its Affixes are the ones that were stripped from the query to find it
+	Word      Word
+	Direction Direction
+	// More than one word of the query was used up, like "tìtxen si"
+	Multiword bool
+	// How well the word fits the query, between 0 and 1, see SortByScore
+	Score float64
+	// For ToNavi, the language of the definition the query was found in.
+	// It is another one than asked for if the word has no definition in that language, see FallbackChain.
+	Language string
+}
+
+// SearchResult is everything found for one part of the query
+type SearchResult struct {
+	// The part of the query, e.g. "tìtxen si" for "tìtxen ke si"
+	Query string
+	// Start and End (exclusive) are the indexes of the words of the cleaned query.
+	// A "ke" or "rä'ä" inside a multiword phrase gets its own result within the span of the phrase.
+	Start int
+	End   int
+	// Empty if nothing was found
+	Matches []Match
+}
+
+// Words are the words of all matches
+func (r SearchResult) Words() []Word {
+	words := make([]Word, len(r.Matches))
+	for i, match := range r.Matches {
+		words[i] = match.Word
+	}
+	return words
+}
+
+// SortByScore puts the best matches first.  Matches with the same score keep their order.
+func (r *SearchResult) SortByScore() {
+	sort.SliceStable(r.Matches, func(i, j int) bool {
+		return r.Matches[i].Score > r.Matches[j].Score
+	})
+}
+
+// How much worse a match gets
+const (
+	// for every affix stripped off the query
+	scoreAffix = 0.1
+	// if the query only matched with reef or loose spelling
+	scoreDialect = 0.2
+	// for a word of a definition instead of a Na'vi word
+	scoreNatlang = 0.1
+	// for every word before the query in the definition
+	scoreDefinitionPosition = 0.02
+	// if the query is only in the parentheses of the definition
+	scoreParenthesis = 0.3
+	// if the query is only in the definition in another form, see NatlangOptions
+	scoreNormalized = 0.05
+	// if the query is in the definition of a fallback language
+	scoreFallback = 0.05
+	// if the IPA of the query only matched without stress marks and syllable dots
+	scoreIPA = 0.1
+	// if the IPA of the query only sounds like the one of the word
+	scoreSoundsLike = 0.3
+	// if the words of the query are in the definition, but not next to each other
+	scoreNotAdjacent = 0.1
+	// no match is worse than this
+	scoreMin = 0.05
+)
+
+// Score a Na'vi word found for the query.  A bare word spelled like the query gets 1.
+func naviScore(query string, word Word) float64 {
+	affixes := len(word.Affixes.Prefix) + len(word.Affixes.Infix) + len(word.Affixes.Suffix) + len(word.Affixes.Lenition)
+	score := 1 - scoreAffix*float64(affixes)
+
+	// Infixes and lenition change the word itself, everything else leaves it in the query
+	if len(word.Affixes.Infix) == 0 && len(word.Affixes.Lenition) == 0 &&
+		!strings.Contains(scoreNormalize(query), scoreNormalize(word.Navi)) {
+		score -= scoreDialect
+	}
+
+	return max(score, scoreMin)
+}
+
+// Score a word found by the query in its definition.
+// The earlier the query is in the definition, the better.
+// With a normalize function, the words are compared normalized, but an exact match is still better.
+func natlangScore(query string, definition string, normalize func(term string) string) float64 {
+	score := 1 - scoreNatlang
+
+	if normalize != nil {
+		if termPosition(SearchTerms(definition, false), query, nil) < 0 {
+			score -= scoreNormalized
+		}
+		query = normalize(query)
+	}
+
+	position := termPosition(SearchTerms(definition, true), query, normalize)
+	if position < 0 {
+		score -= scoreParenthesis
+		position = termPosition(SearchTerms(definition, false), query, normalize)
+	}
+	score -= scoreDefinitionPosition * float64(max(position, 0))
+
+	return max(score, scoreMin)
+}
+
+// Position of the query among the terms, SearchTerms leaves empty ones before parentheses
+func termPosition(terms []string, query string, normalize func(term string) string) int {
+	position := 0
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		if normalize != nil {
+			term = normalize(term)
+		}
+		if term == query {
+			return position
+		}
+		position++
+	}
+	return -1
+}
+
+func scoreNormalize(navi string) string {
+	navi = strings.ToLower(navi)
+	navi = strings.ReplaceAll(navi, "’", "'")
+	navi = strings.ReplaceAll(navi, "+", "")
+	return strings.ReplaceAll(navi, "ù", "u")
+}
+
+// Turn a result of TranslateFromNaviHashHelper, the query followed by the words, into a SearchResult.
+// The helper looked at allWords[start:end].
+func newSearchResult(words []Word, allWords []string, start int, end int) SearchResult {
+	result := SearchResult{Query: words[0].Navi, Start: start, End: end}
+
+	// A lone "ke" or "rä'ä" has its own place in the phrase
+	if end-start > 1 && !strings.Contains(result.Query, " ") {
+		for i := start; i < end; i++ {
+			if allWords[i] == result.Query {
+				result.Start, result.End = i, i+1
+				break
+			}
+		}
+	}
+
+	for _, word := range words[1:] {
+		result.Matches = append(result.Matches, Match{
+			Word:      word,
+			Direction: FromNavi,
+			Multiword: result.End-result.Start > 1 && strings.Contains(word.Navi, " "),
+			Score:     naviScore(result.Query, word),
+		})
+	}
+	return result
+}
+
+// The old format of the results: the query as first word, followed by the words found
+func flattenResults(results []SearchResult) [][]Word {
+	if results == nil {
+		return nil
+	}
+	flat := make([][]Word, len(results))
+	for i, result := range results {
+		flat[i] = append([]Word{simpleWord(result.Query)}, result.Words()...)
+	}
+	return flat
+}
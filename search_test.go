@@ -0,0 +1,169 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testSearchDictionary(t *testing.T) *Dictionary {
+	t.Helper()
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "si", "ˈsi", "s<0><1><2>i", "vin.", "1", "si", "s..i", "do"),
+		testDictRow("3", "tìtxen si", "tɪ.ˈt'ɛn ˈsi", "tìtxen s<0><1><2>i", "vin.", "2", "tì-txen si", "tìtxen s..i", "wake up"),
+		testDictRow("4", "ke", "ˈkɛ", "NULL", "adv.", "1", "ke", "NULL", "not"),
+		testDictRow("5", "eylan", "ˈɛj.lan", "NULL", "n.", "1", "ey-lan", "NULL", "friend"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestSearchFromNavi(t *testing.T) {
+	d := testSearchDictionary(t)
+
+	results, err := d.SearchFromNavi("tuteti tìtxen ke si", true, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type found struct {
+		query      string
+		start, end int
+		navi       []string
+		multiword  []bool
+	}
+	var got []found
+	for _, result := range results {
+		f := found{query: result.Query, start: result.Start, end: result.End}
+		for _, match := range result.Matches {
+			f.navi = append(f.navi, match.Word.Navi)
+			f.multiword = append(f.multiword, match.Multiword)
+			if match.Direction != FromNavi {
+				t.Errorf("%s Direction = %s", match.Word.Navi, match.Direction)
+			}
+		}
+		got = append(got, f)
+	}
+	want := []found{
+		{"tuteti", 0, 1, []string{"tute"}, []bool{false}},
+		{"ke", 2, 3, []string{"ke"}, []bool{false}},
+		{"tìtxen si", 1, 4, []string{"tìtxen si"}, []bool{true}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchFromNavi() =\n%+v\nwant\n%+v", got, want)
+	}
+	if suffixes := results[0].Matches[0].Word.Affixes.Suffix; !reflect.DeepEqual(suffixes, []string{"ti"}) {
+		t.Errorf("tuteti Affixes.Suffix = %v", suffixes)
+	}
+
+	// the old API puts the query first
+	old, err := d.TranslateFromNaviHash("tuteti tìtxen ke si", true, false, false)
+	if err != nil || len(old) != 3 {
+		t.Fatalf("TranslateFromNaviHash() = %v, %v", old, err)
+	}
+	for i, words := range old {
+		if words[0].Navi != results[i].Query || !reflect.DeepEqual(words[1:], results[i].Words()) {
+			t.Errorf("TranslateFromNaviHash()[%d] = %v, want the query and %v", i, words, results[i].Words())
+		}
+	}
+
+	if results, err := d.SearchFromNavi("  ", true, false, false); results != nil || err != nil {
+		t.Errorf("SearchFromNavi() of nothing = %v, %v", results, err)
+	}
+	if old, _ := d.TranslateFromNaviHash("  ", true, false, false); old != nil {
+		t.Errorf("TranslateFromNaviHash() of nothing = %v", old)
+	}
+}
+
+func TestSearchBidirectional(t *testing.T) {
+	d := testSearchDictionary(t)
+
+	results, err := d.SearchBidirectional("friend eylan", false, "en", false)
+	if err != nil || len(results) != 2 {
+		t.Fatalf("SearchBidirectional() = %+v, %v", results, err)
+	}
+	if results[0].Query != "friend" || len(results[0].Matches) != 1 ||
+		results[0].Matches[0].Word.Navi != "eylan" || results[0].Matches[0].Direction != ToNavi {
+		t.Errorf("SearchBidirectional()[0] = %+v", results[0])
+	}
+	if results[1].Start != 1 || len(results[1].Matches) != 1 || results[1].Matches[0].Direction != FromNavi {
+		t.Errorf("SearchBidirectional()[1] = %+v", results[1])
+	}
+
+	toNavi := d.SearchToNavi("person nothing", "en")
+	if len(toNavi) != 2 || toNavi[0].Matches[0].Word.Navi != "tute" || toNavi[1].Start != 1 || len(toNavi[1].Matches) != 0 {
+		t.Errorf("SearchToNavi() = %+v", toNavi)
+	}
+	old := d.TranslateToNaviHash("person nothing", "en")
+	if len(old) != 2 || old[0][0].Navi != "person" || old[0][1].Navi != "tute" || len(old[1]) != 1 {
+		t.Errorf("TranslateToNaviHash() = %v", old)
+	}
+}
+
+func TestSearchScores(t *testing.T) {
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "kaltxì", "kal.ˈt'ɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+		testDictRow("3", "eylan", "ˈɛj.lan", "NULL", "n.", "1", "ey-lan", "NULL", "friend"),
+		testDictRow("4", "tsmukan", "ˈt͡smu.kan", "NULL", "n.", "1", "tsmu-kan", "NULL", "brother (friend)"),
+		testDictRow("5", "'eylan", "ˈʔɛj.lan", "NULL", "n.", "1", "'ey-lan", "NULL", "good friend"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	scores := func(results []SearchResult) map[string]float64 {
+		got := map[string]float64{}
+		for _, result := range results {
+			for _, match := range result.Matches {
+				got[result.Query+" "+match.Word.Navi] = match.Score
+			}
+		}
+		return got
+	}
+	near := func(a, b float64) bool {
+		return a-b < 1e-9 && b-a < 1e-9
+	}
+
+	results, err := d.SearchFromNavi("tute tuteti aysute kaldì", true, false, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := scores(results)
+	for query, want := range map[string]float64{
+		"tute tute":    1,
+		"tuteti tute":  0.9, // -ti
+		"aysute tute":  0.8, // ay+ and the lenition
+		"kaldì kaltxì": 0.8, // reef
+	} {
+		if score, ok := got[query]; !ok || !near(score, want) {
+			t.Errorf("score of %s = %v, want %v (all: %v)", query, score, want, got)
+		}
+	}
+
+	result := d.SearchToNavi("friend", "en")[0]
+	got = scores([]SearchResult{result})
+	for query, want := range map[string]float64{
+		"friend eylan":   0.9,
+		"friend 'eylan":  0.88,
+		"friend tsmukan": 0.58,
+	} {
+		if !near(got[query], want) {
+			t.Errorf("score of %s = %v, want %v", query, got[query], want)
+		}
+	}
+
+	result.SortByScore()
+	if navi := wordNavis(result.Words()); !reflect.DeepEqual(navi, []string{"eylan", "'eylan", "tsmukan"}) {
+		t.Errorf("SortByScore() = %v", navi)
+	}
+}
+
+func wordNavis(words []Word) (navi []string) {
+	for _, word := range words {
+		navi = append(navi, word.Navi)
+	}
+	return
+}
@@ -0,0 +1,282 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. snapshot.go saves and restores the built caches.
+package fwew_lib
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// Change this whenever snapshotData changes, so old snapshots get rebuilt
+const snapshotFormat = 7
+
+// Written before the data, so an outdated snapshot is noticed without decoding everything
+type snapshotHeader struct {
+	Format int
+	// The library version that built it, the caches may be built differently in another one
+	Library string
+	// Hash of the dictionary it was built from, see HashedSource
+	DictBuild string
+}
+
+// Everything Load() builds
+type snapshotData struct {
+	Words []Word
+
+	HashLoose      map[string][]Word
+	HashStrict     map[string][]Word
+	HashStrictReef map[string][]Word
+	HashIPA        map[string][]Word
+	HashSoundsLike map[string][]Word
+
+	Hash2            MetaDict
+	Hash2Parenthesis MetaDict
+	Hash2Positions   positionDict
+	Hash2Normalized  MetaDict
+
+	Homonyms string
+	Oddballs string
+	MultiIPA string
+
+	Nkx    []string
+	NkxSub map[string]string
+
+	Multiwords      map[string][][]string
+	MultiwordsLoose map[string][][]string
+	MultiwordsReef  map[string][][]string
+
+	// The languages of the definitions, the dictionary header has them too
+	Languages []string
+}
+
+func snapshotLibrary() string {
+	return fmt.Sprintf("%d.%d.%d", Version.Major, Version.Minor, Version.Patch)
+}
+
+// the hash of what the source currently holds
+func (d *Dictionary) sourceHash() (string, error) {
+	source, ok := d.Source().(HashedSource)
+	if !ok {
+		return "", SnapshotNotSupported
+	}
+	return source.Hash()
+}
+
+// SaveSnapshot writes every cache of the dictionary to the file at path.
+// The dictionary is loaded first, if it isn't already.
+func (d *Dictionary) SaveSnapshot(path string) error {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
+	build, err := d.sourceHash()
+	if err != nil {
+		return err
+	}
+
+	d.lock.RLock()
+	loaded := d.wordsCached && d.hashCached && d.hash2Cached
+	d.lock.RUnlock()
+	if !loaded {
+		err = d.reload()
+		if err != nil {
+			return err
+		}
+	}
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.saveSnapshot(path, build)
+}
+
+// LoadSnapshot replaces the caches with the ones saved at path without rebuilding anything.
+// It fails with SnapshotOutdated if the source changed since the snapshot was saved.
+// The old caches stay in use if it fails.
+func (d *Dictionary) LoadSnapshot(path string) error {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
+	build, err := d.sourceHash()
+	if err != nil {
+		return err
+	}
+
+	fresh := NewDictionary(d.Source())
+	err = fresh.loadSnapshot(path, build)
+	if err != nil {
+		return err
+	}
+
+	d.swap(fresh)
+	return nil
+}
+
+// LoadWithSnapshot loads the dictionary from the snapshot at path if it is up-to-date.
+// Otherwise, it does a full Load() and saves a new snapshot for the next time.
+func (d *Dictionary) LoadWithSnapshot(path string) error {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
+	// without a hash there is no way to tell if a snapshot is current
+	build, err := d.sourceHash()
+	if err != nil {
+		return d.reload()
+	}
+
+	fresh := NewDictionary(d.Source())
+	if err = fresh.loadSnapshot(path, build); err == nil {
+		d.swap(fresh)
+		return nil
+	}
+
+	fresh = NewDictionary(d.Source())
+	err = fresh.load()
+	if err != nil {
+		return err
+	}
+	d.swap(fresh)
+
+	// the dictionary works even if the snapshot can't be written
+	if err = fresh.saveSnapshot(path, build); err != nil {
+		log.Printf("Error saving the dictionary snapshot: %s", err)
+	}
+
+	return nil
+}
+
+func (d *Dictionary) saveSnapshot(path string, build string) error {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	// write next to the old one and swap, so a crash never leaves half a snapshot
+	temp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	out := bufio.NewWriter(temp)
+	encoder := gob.NewEncoder(out)
+	err = encoder.Encode(snapshotHeader{
+		Format:    snapshotFormat,
+		Library:   snapshotLibrary(),
+		DictBuild: build,
+	})
+	if err == nil {
+		err = encoder.Encode(snapshotData{
+			Words:            d.words,
+			HashLoose:        d.hashLoose,
+			HashStrict:       d.hashStrict,
+			HashStrictReef:   d.hashStrictReef,
+			HashIPA:          d.hashIPA,
+			HashSoundsLike:   d.hashSoundsLike,
+			Hash2:            d.hash2,
+			Hash2Parenthesis: d.hash2Parenthesis,
+			Hash2Positions:   d.hash2Positions,
+			Hash2Normalized:  d.hash2Normalized,
+			Homonyms:         d.homonyms,
+			Oddballs:         d.oddballs,
+			MultiIPA:         d.multiIPA,
+			Nkx:              d.nkx,
+			NkxSub:           d.nkxSub,
+			Multiwords:       d.multiwords,
+			MultiwordsLoose:  d.multiwordsLoose,
+			MultiwordsReef:   d.multiwordsReef,
+			Languages:        d.languages,
+		})
+	}
+	if err == nil {
+		err = out.Flush()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
+
+func (d *Dictionary) loadSnapshot(path string, build string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := gob.NewDecoder(bufio.NewReader(file))
+
+	var header snapshotHeader
+	err = decoder.Decode(&header)
+	if err != nil {
+		return err
+	}
+	if header.Format != snapshotFormat || header.Library != snapshotLibrary() || header.DictBuild != build {
+		return SnapshotOutdated
+	}
+
+	var data snapshotData
+	err = decoder.Decode(&data)
+	if err != nil {
+		return err
+	}
+
+	d.words = data.Words
+	d.wordsCached = true
+
+	d.hashLoose = data.HashLoose
+	d.hashStrict = data.HashStrict
+	d.hashStrictReef = data.HashStrictReef
+	d.hashIPA = emptyIfNil(data.HashIPA)
+	d.hashSoundsLike = emptyIfNil(data.HashSoundsLike)
+	d.homonyms = data.Homonyms
+	d.oddballs = data.Oddballs
+	d.multiIPA = data.MultiIPA
+	d.hashCached = true
+
+	d.hash2 = data.Hash2
+	d.hash2Parenthesis = data.Hash2Parenthesis
+	d.hash2Positions = emptyIfNil(data.Hash2Positions)
+	d.hash2Normalized = emptyIfNil(data.Hash2Normalized)
+	d.hash2Cached = true
+
+	// gob leaves out empty maps and slices
+	d.nkx = data.Nkx
+	if d.nkx == nil {
+		d.nkx = []string{}
+	}
+	d.nkxSub = emptyIfNil(data.NkxSub)
+	d.multiwords = emptyIfNil(data.Multiwords)
+	d.multiwordsLoose = emptyIfNil(data.MultiwordsLoose)
+	d.multiwordsReef = emptyIfNil(data.MultiwordsReef)
+
+	d.languages = data.Languages
+
+	return nil
+}
+
+func emptyIfNil[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return map[K]V{}
+	}
+	return m
+}
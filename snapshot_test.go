@@ -0,0 +1,142 @@
+package fwew_lib
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// a FileSource that counts how often the words are read
+type countingFileSource struct {
+	*FileSource
+	reads *int
+}
+
+func (s countingFileSource) Run(f func(word Word) error) error {
+	*s.reads++
+	return s.FileSource.Run(f)
+}
+
+func TestDictionarySnapshot(t *testing.T) {
+	rows := []string{
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "female person"),
+		testDictRow("3", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello (greeting)"),
+		testDictRow("4", "tsun si", "ˈt͡sun ˈsi", "NULL", "vin.", "1", "tsun si", "NULL", "be able"),
+		testDictRow("5", "fìtxan", "fɪ.ˈtʼan or ˈfɪ.tʼan", "NULL", "adv.", "2", "fì-txan", "NULL", "so (much)"),
+	}
+	dictPath := writeTestDict(t, rows...)
+	snapshotPath := filepath.Join(t.TempDir(), "dictionary.snapshot")
+
+	built := NewDictionary(NewFileSource(dictPath))
+	if err := built.SaveSnapshot(snapshotPath); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+
+	reads := 0
+	restored := NewDictionary(countingFileSource{NewFileSource(dictPath), &reads})
+	if err := restored.LoadSnapshot(snapshotPath); err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	if reads != 0 {
+		t.Errorf("LoadSnapshot() read the dictionary %d times", reads)
+	}
+
+	if len(restored.words) != len(built.words) ||
+		!reflect.DeepEqual(restored.hash2, built.hash2) ||
+		!reflect.DeepEqual(restored.hash2Positions, built.hash2Positions) ||
+		!reflect.DeepEqual(restored.multiwords, built.multiwords) ||
+		!reflect.DeepEqual(restored.nkxSub, built.nkxSub) {
+		t.Errorf("restored caches differ from the built ones")
+	}
+	if restored.homonyms != built.homonyms || restored.oddballs != built.oddballs || restored.multiIPA != built.multiIPA {
+		t.Errorf("restored lists = %q %q %q, want %q %q %q", restored.homonyms, restored.oddballs, restored.multiIPA,
+			built.homonyms, built.oddballs, built.multiIPA)
+	}
+
+	got, err := restored.TranslateFromNaviHash("tsun si", true, false, false)
+	want, _ := built.TranslateFromNaviHash("tsun si", true, false, false)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("restored TranslateFromNaviHash() = %v, %v, want %v", got, err, want)
+	}
+	if got := restored.TranslateToNaviHash("hello", "en"); len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("restored TranslateToNaviHash() = %v", got)
+	}
+
+	// change the dictionary, the snapshot is outdated now
+	rows = append(rows, testDictRow("6", "tsmukan", "ˈt͡smu.kan", "NULL", "n.", "1", "tsmu-kan", "NULL", "brother"))
+	content, _ := os.ReadFile(writeTestDict(t, rows...))
+	if err := os.WriteFile(dictPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewDictionary(NewFileSource(dictPath)).LoadSnapshot(snapshotPath); !errors.Is(err, SnapshotOutdated) {
+		t.Errorf("LoadSnapshot() after a change error = %v, want SnapshotOutdated", err)
+	}
+
+	reads = 0
+	rebuilt := NewDictionary(countingFileSource{NewFileSource(dictPath), &reads})
+	if err := rebuilt.LoadWithSnapshot(snapshotPath); err != nil {
+		t.Fatalf("LoadWithSnapshot() error = %v", err)
+	}
+	if reads != 1 || rebuilt.GetDictSizeSimple() != 6 {
+		t.Errorf("LoadWithSnapshot() read %d times and has %d words", reads, rebuilt.GetDictSizeSimple())
+	}
+
+	// the rebuild saved a new snapshot
+	reads = 0
+	again := NewDictionary(countingFileSource{NewFileSource(dictPath), &reads})
+	if err := again.LoadWithSnapshot(snapshotPath); err != nil {
+		t.Fatalf("LoadWithSnapshot() error = %v", err)
+	}
+	if reads != 0 || again.GetDictSizeSimple() != 6 {
+		t.Errorf("second LoadWithSnapshot() read %d times and has %d words", reads, again.GetDictSizeSimple())
+	}
+}
+
+// A language only the dictionary header has is one of the dictionary's again after loading the snapshot
+func TestDictionarySnapshotLanguages(t *testing.T) {
+	row := testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person")
+	dictPath := filepath.Join(t.TempDir(), dictFileName)
+	if err := os.WriteFile(dictPath, []byte(testDictHeader+"\tcs\n"+row+"\tčlověk\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	snapshotPath := filepath.Join(t.TempDir(), "dictionary.snapshot")
+	if err := NewDictionary(NewFileSource(dictPath)).SaveSnapshot(snapshotPath); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+
+	restored := NewDictionary(NewFileSource(dictPath))
+	if err := restored.LoadSnapshot(snapshotPath); err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	if codes := restored.LanguageCodes(); !slices.Contains(codes, "cs") {
+		t.Errorf("LanguageCodes() after LoadSnapshot() = %v", codes)
+	}
+	if matches := restored.SearchToNavi("člověk", "cs")[0].Matches; len(matches) != 1 || matches[0].Word.Navi != "tute" {
+		t.Errorf("SearchToNavi(člověk, cs) = %v", matches)
+	}
+}
+
+func TestDictionarySnapshotNotSupported(t *testing.T) {
+	reads := 0
+	d := NewDictionary(countingSource{NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	)), &reads})
+
+	snapshotPath := filepath.Join(t.TempDir(), "dictionary.snapshot")
+	if err := d.SaveSnapshot(snapshotPath); !errors.Is(err, SnapshotNotSupported) {
+		t.Errorf("SaveSnapshot() error = %v, want SnapshotNotSupported", err)
+	}
+
+	// still loads, just without a snapshot
+	if err := d.LoadWithSnapshot(snapshotPath); err != nil || d.GetDictSizeSimple() != 1 {
+		t.Errorf("LoadWithSnapshot() = %v with %d words", err, d.GetDictSizeSimple())
+	}
+	if _, err := os.Stat(snapshotPath); !os.IsNotExist(err) {
+		t.Errorf("LoadWithSnapshot() wrote a snapshot without a hash")
+	}
+}
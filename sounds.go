@@ -0,0 +1,169 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. sounds.go finds words by their pronunciation.
+package fwew_lib
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Marks of the IPA that don't change the sounds, or are often left out when transcribing by ear
+var ipaIgnored = strings.NewReplacer(
+	"ˈ", "", "ˌ", "", ".", "", "·", "", "[", "", "]", "",
+	"͡", "", // tie bar, t͡s is ts
+	"ʼ", "'", "’", "'", // ejectives
+	"ɡ", "g", // the IPA g
+)
+
+// romanization2 with the ignored marks taken out of the IPA, longest first
+var soundsLikeKeys []string
+var soundsLikeRomanization = map[string]string{}
+
+// The sounds of the reef dialect, and the ones only told apart by the spelling
+var soundsLikeFolding = strings.NewReplacer(
+	"ng", "ng", // not the reef g
+	"ù", "u", "sh", "sy", "ch", "tsy", "z", "ts",
+	"b", "px", "d", "tx", "g", "kx",
+)
+
+func init() {
+	for ipa, romanized := range romanization2 {
+		ipa = ipaIgnored.Replace(ipa)
+		if strings.TrimSpace(ipa) == "" {
+			continue
+		}
+		// ʒ and tʃ, the table has no other keys that become the same
+		if _, ok := soundsLikeRomanization[ipa]; !ok {
+			soundsLikeKeys = append(soundsLikeKeys, ipa)
+		}
+		soundsLikeRomanization[ipa] = romanized
+	}
+	sort.Slice(soundsLikeKeys, func(i, j int) bool {
+		if len(soundsLikeKeys[i]) != len(soundsLikeKeys[j]) {
+			return len(soundsLikeKeys[i]) > len(soundsLikeKeys[j])
+		}
+		return soundsLikeKeys[i] < soundsLikeKeys[j]
+	})
+}
+
+// The IPA without stress marks and syllable dots
+func ipaKey(ipa string) string {
+	ipa = ipaIgnored.Replace(strings.ToLower(ipa))
+	return strings.Join(strings.Fields(ipa), " ")
+}
+
+// What the IPA sounds like, spelled the Na'vi way.
+// Letters that aren't in romanization2 stay, so plain Na'vi letters work, too.
+func soundsLikeKey(ipa string) string {
+	ipa = ipaKey(ipa)
+	var romanized strings.Builder
+	for len(ipa) > 0 {
+		found := false
+		for _, key := range soundsLikeKeys {
+			if strings.HasPrefix(ipa, key) {
+				romanized.WriteString(soundsLikeRomanization[key])
+				ipa = ipa[len(key):]
+				found = true
+				break
+			}
+		}
+		if !found {
+			r, size := utf8.DecodeRuneInString(ipa)
+			romanized.WriteRune(r)
+			ipa = ipa[size:]
+		}
+	}
+	return soundsLikeFolding.Replace(romanized.String())
+}
+
+// The pronunciations of the word, the IPA can have more than one
+func wordIPAs(word Word) []string {
+	var ipas []string
+	for _, ipa := range strings.Split(word.IPA, " or ") {
+		if ipa = strings.TrimSpace(ipa); ipa != "" {
+			ipas = append(ipas, ipa)
+		}
+	}
+	return ipas
+}
+
+// Put the word into hashIPA and hashSoundsLike under every one of its pronunciations
+func (d *Dictionary) hashPronunciation(word Word) {
+	for _, ipa := range wordIPAs(word) {
+		ipa = strings.Trim(ipa, "[]")
+		d.hashIPA[ipaKey(ipa)] = appendOnce(d.hashIPA[ipaKey(ipa)], word)
+		d.hashSoundsLike[soundsLikeKey(ipa)] = appendOnce(d.hashSoundsLike[soundsLikeKey(ipa)], word)
+	}
+}
+
+// The pronunciations of a word can have the same key, it is only put in once
+func appendOnce(words []Word, word Word) []Word {
+	if len(words) > 0 && words[len(words)-1].ID == word.ID {
+		return words
+	}
+	return append(words, word)
+}
+
+// SearchIPA finds the words pronounced like the IPA of the query, e.g. "ˈtu.tɛ" or "tutɛ".
+// Stress marks and syllable dots don't have to be there.
+// With soundsLike, both the query and the IPA of the words are spelled the Na'vi way first (see romanization2),
+// so "tutɛ" also finds words with another IPA for the same sounds, including reef ones.
+// The matches are alphabetized, words without an exact transcription have a lower Score.
+func (d *Dictionary) SearchIPA(query string, soundsLike bool) (result SearchResult) {
+	result.Query = strings.TrimSpace(query)
+	if result.Query == "" {
+		return
+	}
+	result.End = len(strings.Fields(result.Query))
+
+	key := ipaKey(query)
+	d.lock.RLock()
+	words := d.hashIPA[key]
+	if soundsLike {
+		words = append(slices.Clip(words), d.hashSoundsLike[soundsLikeKey(query)]...)
+	}
+	d.lock.RUnlock()
+
+	var found []Word
+	scores := map[string]float64{}
+	for _, word := range words {
+		if _, ok := scores[word.ID]; ok {
+			continue
+		}
+		score := 0.0
+		for _, ipa := range wordIPAs(word) {
+			ipa = strings.Trim(ipa, "[]")
+			switch {
+			case ipa == result.Query:
+				score = max(score, 1)
+			case ipaKey(ipa) == key:
+				score = max(score, 1-scoreIPA)
+			case soundsLike:
+				// the rest came from hashSoundsLike
+				score = max(score, 1-scoreSoundsLike)
+			}
+		}
+		found = AppendAndAlphabetize(found, word)
+		scores[word.ID] = score
+	}
+
+	for _, word := range found {
+		result.Matches = append(result.Matches, Match{Word: word, Direction: FromIPA, Score: scores[word.ID]})
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchIPA(t *testing.T) {
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+		testDictRow("2", "tuté", "ˈtu.tɛ", "NULL", "n.", "1", "tu-té", "NULL", "female person"),
+		testDictRow("3", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+		testDictRow("4", "ngawng", "ˈŋawŋ", "NULL", "n.", "1", "ngawng", "NULL", "worm"),
+		testDictRow("5", "kxetse", "ˈkʼɛ.t͡sɛ", "NULL", "n.", "1", "kxe-tse", "NULL", "tail"),
+		testDictRow("6", "fpom", "[ˈfpom] or [ˈfpõ]", "NULL", "n.", "1", "fpom", "NULL", "peace"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query      string
+		soundsLike bool
+		want       []string
+		score      float64
+	}{
+		{"ˈtu.tɛ", false, []string{"tuté", "tute"}, 1},
+		{"tutɛ", false, []string{"tuté", "tute"}, 1 - scoreIPA},
+		{" [ˈtu.tɛ] ", false, []string{"tuté", "tute"}, 1 - scoreIPA},
+		{"kalt'ɪ", false, []string{"kaltxì"}, 1 - scoreIPA},
+		{"ŋawŋ", false, []string{"ngawng"}, 1 - scoreIPA},
+		{"fpõ", false, []string{"fpom"}, 1 - scoreIPA},
+		{"ˈkʼɛ.tsɛ", false, []string{"kxetse"}, 1 - scoreIPA},
+		{"tute", false, nil, 0},
+		{"tɛ", true, nil, 0},
+		// spelled the Na'vi way
+		{"tute", true, []string{"tuté", "tute"}, 1 - scoreSoundsLike},
+		{"ngawng", true, []string{"ngawng"}, 1 - scoreSoundsLike},
+		// reef g and z
+		{"ˈɡɛ.zɛ", true, []string{"kxetse"}, 1 - scoreSoundsLike},
+		{"ˈɡɛ.zɛ", false, nil, 0},
+	}
+	for _, tt := range tests {
+		result := d.SearchIPA(tt.query, tt.soundsLike)
+		if got := wordNavis(result.Words()); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SearchIPA(%s, %v) = %v, want %v", tt.query, tt.soundsLike, got, tt.want)
+			continue
+		}
+		for _, match := range result.Matches {
+			if match.Score != tt.score || match.Direction != FromIPA {
+				t.Errorf("SearchIPA(%s, %v) %s = %v %v, want score %v", tt.query, tt.soundsLike, match.Word.Navi, match.Direction, match.Score, tt.score)
+			}
+		}
+	}
+
+	if result := d.SearchIPA("  ", true); result.Matches != nil || result.End != 0 {
+		t.Errorf("SearchIPA() of nothing = %+v", result)
+	}
+
+	// the keys are made once by Load, both pronunciations of fpom have the same sound
+	if got := wordNavis(d.hashSoundsLike["fpom"]); !reflect.DeepEqual(got, []string{"fpom"}) {
+		t.Errorf("hashSoundsLike[fpom] = %v", got)
+	}
+	if got := wordNavis(d.hashIPA["tutɛ"]); !reflect.DeepEqual(got, []string{"tute", "tuté"}) {
+		t.Errorf("hashIPA[tutɛ] = %v", got)
+	}
+}
@@ -0,0 +1,310 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. source.go is home to the places a Dictionary can read its words from.
+package fwew_lib
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// DictionarySource yields every Word of a dictionary.
+type DictionarySource interface {
+	// Name says where the words come from, e.g. "File" or "SQL"
+	Name() string
+	// Run calls f once for every word, in dictionary order.
+	// It stops and returns the error as soon as f returns one.
+	Run(f func(word Word) error) error
+}
+
+// HashedSource is a DictionarySource that knows a hash of its content
+// without handing out every word, so snapshots can be checked against it.
+type HashedSource interface {
+	DictionarySource
+	Hash() (string, error)
+}
+
+// FileSource reads the tab separated dictionary file.
+type FileSource struct {
+	// Path of the dictionary file, empty means FindDictionaryFile()
+	Path string
+}
+
+// NewFileSource reads the dictionary file at path.
+// Give an empty string to use FindDictionaryFile(), or the fallback dictionary if no file is found.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: path}
+}
+
+func (s *FileSource) Name() string {
+	return "File"
+}
+
+// Hash is the short sha1 of the file, the same as Version.DictBuild
+func (s *FileSource) Hash() (string, error) {
+	if fallback := s.fallback(); fallback != nil {
+		return fallback.Hash()
+	}
+	dictionaryFile, err := s.file()
+	if err != nil {
+		return "", err
+	}
+	return fileHash(dictionaryFile)
+}
+
+func (s *FileSource) file() (string, error) {
+	if s.Path != "" {
+		return s.Path, nil
+	}
+	if dictionaryFile := FindDictionaryFile(); dictionaryFile != "" {
+		return dictionaryFile, nil
+	}
+	return "", DictionaryNotFound
+}
+
+// the fallback dictionary, if there is one and no file is found
+func (s *FileSource) fallback() *EmbeddedSource {
+	if s.Path != "" || fallbackDictionary == nil || FindDictionaryFile() != "" {
+		return nil
+	}
+	return NewEmbeddedSource(fallbackDictionary)
+}
+
+func (s *FileSource) Run(f func(word Word) error) error {
+	if fallback := s.fallback(); fallback != nil {
+		return fallback.Run(f)
+	}
+	dictionaryFile, err := s.file()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(dictionaryFile)
+	if err != nil {
+		log.Printf("Error opening the dictionary file %s", err)
+		return err
+	}
+	defer file.Close()
+
+	return readDictionary(file, f)
+}
+
+// Parse a tab separated dictionary, the first line is the header.
+// A header without the needed columns or a line with fewer fields than the header is an InvalidDictionary.
+func readDictionary(r io.Reader, f func(word Word) error) error {
+	scanner := bufio.NewScanner(r)
+
+	var header []string
+	var pos dictPos
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		// Split line at \t so we get all information
+		fields := strings.Split(scanner.Text(), "\t")
+
+		// When first then this is the header
+		if header == nil {
+			if missing := missingColumns(fields); len(missing) > 0 {
+				return InvalidDictionary.wrap(fmt.Errorf("no %s column", strings.Join(missing, ", ")))
+			}
+			header = fields
+			pos = readDictPos(fields)
+			continue
+		}
+
+		if len(fields) < len(header) {
+			return InvalidDictionary.wrap(fmt.Errorf("line %d has %d fields instead of %d", line, len(fields), len(header)))
+		}
+
+		// Put the stuff from fields into the Word struct
+		err := f(newWord(fields, pos))
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// EmbeddedSource reads a dictionary file that is compiled into the program, e.g. with go:embed.
+type EmbeddedSource struct {
+	Data []byte
+}
+
+// NewEmbeddedSource reads the contents of a dictionary file.
+func NewEmbeddedSource(data []byte) *EmbeddedSource {
+	return &EmbeddedSource{Data: data}
+}
+
+func (s *EmbeddedSource) Name() string {
+	return "Embedded"
+}
+
+// Hash is the short sha1 of the data, the same a file with this content would have
+func (s *EmbeddedSource) Hash() (string, error) {
+	return bytesHash(s.Data), nil
+}
+
+func (s *EmbeddedSource) Run(f func(word Word) error) error {
+	return readDictionary(bytes.NewReader(s.Data), f)
+}
+
+// MySQLSource reads the dictionary straight from the fwedit database.
+type MySQLSource struct {
+	// DataSourceName as understood by github.com/go-sql-driver/mysql
+	DataSourceName string
+}
+
+// NewMySQLSource connects to the database name on host with the given credentials.
+// Nothing is connected until the words are read.
+func NewMySQLSource(user, pass, host, name string) *MySQLSource {
+	return &MySQLSource{DataSourceName: fmt.Sprintf("%s:%s@tcp(%s)/%s", user, pass, host, name)}
+}
+
+// NewMySQLSourceFromEnv takes the credentials from FW_USER, FW_PASS, FW_HOST and FW_DB.
+func NewMySQLSourceFromEnv() *MySQLSource {
+	return NewMySQLSource(os.Getenv("FW_USER"), os.Getenv("FW_PASS"), os.Getenv("FW_HOST"), os.Getenv("FW_DB"))
+}
+
+func (s *MySQLSource) Name() string {
+	return "SQL"
+}
+
+func (s *MySQLSource) Run(f func(word Word) error) error {
+	db, err := sql.Open("mysql", s.DataSourceName)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// every definition of every word, in whatever languages there are
+	definitions, codes, err := readLocalizedWords(db)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Query("SELECT " +
+		"m.id, m.navi, m.ipa, m.infixes, m.partOfSpeech, s.source, b.stressed, b.syllables, b.infixDots " +
+		"FROM fwedit_metaWords AS m " +
+		"INNER JOIN fwedit_sources AS s ON (m.id = s.id) " +
+		"INNER JOIN fwedit_breakdown AS b ON (s.id = b.id)")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var w Word
+		err = rows.Scan(&w.ID, &w.Navi, &w.IPA, &w.InfixLocations, &w.PartOfSpeech, &w.Source, &w.Stressed,
+			&w.Syllables, &w.InfixDots)
+
+		if err != nil {
+			return err
+		}
+
+		w.Definitions = definitions[w.ID]
+		if w.Definitions == nil {
+			w.Definitions = map[string]string{}
+		}
+		// like an empty field of the dictionary file
+		for _, code := range codes {
+			if _, ok := w.Definitions[code]; !ok {
+				w.Definitions[code] = "NULL"
+			}
+		}
+
+		err = f(w)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Definitions by word ID and language code, and the language codes there are
+func readLocalizedWords(db *sql.DB) (definitions map[string]map[string]string, codes []string, err error) {
+	rows, err := db.Query("SELECT id, languageCode, localized FROM fwedit_localizedWords")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	definitions = map[string]map[string]string{}
+	for rows.Next() {
+		var id, code string
+		var localized []byte
+		err = rows.Scan(&id, &code, &localized)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+		if definitions[id] == nil {
+			definitions[id] = map[string]string{}
+		}
+		definitions[id][code] = string(localized)
+	}
+
+	return definitions, codes, rows.Err()
+}
+
+// MemorySource hands out words that are already in memory, mostly useful for tests.
+type MemorySource struct {
+	Words []Word
+}
+
+// NewMemorySource serves exactly the given words.
+func NewMemorySource(words []Word) *MemorySource {
+	return &MemorySource{Words: words}
+}
+
+func (s *MemorySource) Name() string {
+	return "Memory"
+}
+
+// Hash is the short sha1 of all the words
+func (s *MemorySource) Hash() (string, error) {
+	// not gob, it writes the definitions in random order
+	data, err := json.Marshal(s.Words)
+	if err != nil {
+		return "", err
+	}
+	return bytesHash(data), nil
+}
+
+func (s *MemorySource) Run(f func(word Word) error) error {
+	for _, word := range s.Words {
+		err := f(word)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. suggest.go guesses what a misspelled Na'vi word should be.
+package fwew_lib
+
+import (
+	"sort"
+	"strings"
+)
+
+// Suggestion is a headword that is spelled like the query
+type Suggestion struct {
+	Navi string
+	// The number of typos, a learner's confusion like ì for i only counts a bit
+	Distance float64
+}
+
+// How much a typo costs
+const (
+	suggestTypo = 1.0
+	// ä for a, missing x of an ejective, reef b for px, ...
+	suggestConfusion = 0.3
+	// a missing or extra tìftang
+	suggestTìftang = 0.5
+)
+
+// Letters learners mix up, in the compressed form: b is px, d is tx, q is kx, g is ng and c is ts
+var suggestConfusions = map[[2]rune]bool{}
+
+func init() {
+	for _, pair := range []string{
+		"aä", "iì", "uù", "eé", "eä", // missing diacritics and unstressed ä
+		"bp", "dt", "qk", // missing x of the ejectives, reef b, d and g for px, tx and kx
+		"qg",       // reef g for kx, which compresses like ng
+		"gn",       // n for ng
+		"cs", "cz", // s or reef z for ts
+	} {
+		runes := []rune(pair)
+		suggestConfusions[[2]rune{runes[0], runes[1]}] = true
+		suggestConfusions[[2]rune{runes[1], runes[0]}] = true
+	}
+}
+
+// Suggest finds the headwords the query is most likely a misspelling of, the closest first.
+// At most limit are returned, and only ones with at most a typo for every four letters.
+func (d *Dictionary) Suggest(query string, limit int) []Suggestion {
+	query = clean(query)
+	if query == "" || limit <= 0 {
+		return nil
+	}
+	compressedQuery := []rune(compress(query))
+	maxDistance := suggestTypo * float64(1+len(compressedQuery)/4)
+
+	d.lock.RLock()
+	words := d.words
+	d.lock.RUnlock()
+
+	seen := map[string]bool{}
+	var suggestions []Suggestion
+	for _, word := range words {
+		navi := strings.ToLower(strings.ReplaceAll(word.Navi, "+", ""))
+		if seen[navi] {
+			continue
+		}
+		seen[navi] = true
+
+		compressed := []rune(compress(navi))
+		// every letter more or less is at least a missing tìftang
+		lengthDifference := len(compressed) - len(compressedQuery)
+		if suggestTìftang*float64(max(lengthDifference, -lengthDifference)) > maxDistance {
+			continue
+		}
+		distance := suggestDistance(compressedQuery, compressed)
+		if distance <= maxDistance {
+			suggestions = append(suggestions, Suggestion{Navi: word.Navi, Distance: distance})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Distance != suggestions[j].Distance {
+			return suggestions[i].Distance < suggestions[j].Distance
+		}
+		return AlphabetizeHelper(suggestions[i].Navi, suggestions[j].Navi)
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// Damerau-Levenshtein distance (optimal string alignment) with the costs above
+func suggestDistance(a []rune, b []rune) float64 {
+	indel := func(r rune) float64 {
+		if r == '\'' {
+			return suggestTìftang
+		}
+		return suggestTypo
+	}
+	substitute := func(x rune, y rune) float64 {
+		if x == y {
+			return 0
+		}
+		if suggestConfusions[[2]rune{x, y}] {
+			return suggestConfusion
+		}
+		return suggestTypo
+	}
+
+	// three rows are enough for the transpositions
+	previous2 := make([]float64, len(b)+1)
+	previous := make([]float64, len(b)+1)
+	current := make([]float64, len(b)+1)
+	for j := 1; j <= len(b); j++ {
+		previous[j] = previous[j-1] + indel(b[j-1])
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = previous[0] + indel(a[i-1])
+		for j := 1; j <= len(b); j++ {
+			current[j] = min(
+				previous[j]+indel(a[i-1]),
+				current[j-1]+indel(b[j-1]),
+				previous[j-1]+substitute(a[i-1], b[j-1]),
+			)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], previous2[j-2]+suggestTypo)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,53 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	d := NewDictionary(NewMemorySource(testWords(
+		testDictRow("1", "kaltxì", "kal.ˈt'ɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+		testDictRow("2", "irayo", "i.ɾa.ˈjo", "NULL", "intj.", "3", "i-ra-yo", "NULL", "thank you"),
+		testDictRow("3", "tsa'u", "ˈt͡sa.ʔu", "NULL", "dem.", "1", "tsa-'u", "NULL", "that"),
+		testDictRow("4", "tsaw", "ˈt͡saw", "NULL", "pn.", "1", "tsaw", "NULL", "that"),
+		testDictRow("5", "kelku", "ˈkɛl.ku", "NULL", "n.", "1", "kel-ku", "NULL", "home"),
+		testDictRow("6", "kelutral", "kɛ.ˈlu.tɾal", "NULL", "n.", "2", "ke-lu-tral", "NULL", "hometree"),
+		testDictRow("7", "Kelutral", "kɛ.ˈlu.tɾal", "NULL", "n.", "2", "Ke-lu-tral", "NULL", "hometree"),
+	)))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		limit int
+		want  []string
+	}{
+		{"kaltxi", 3, []string{"kaltxì"}},
+		{"kaltki", 3, []string{"kaltxì"}},    // missing x of the ejective
+		{"kaldxì", 3, []string{"kaltxì"}},    // one typo
+		{"irayu", 3, []string{"irayo"}},      // one typo
+		{"irayoo", 3, []string{"irayo"}},     // one letter too much
+		{"tsau", 3, []string{"tsa'u"}},       // missing tìftang, but aw is one letter
+		{"kelutal", 3, []string{"kelutral"}}, // homonyms only once
+		{"kelku", 1, []string{"kelku"}},
+		{"zzzzzz", 3, nil},
+		{"", 3, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var got []string
+			for _, suggestion := range d.Suggest(tt.query, tt.limit) {
+				got = append(got, suggestion.Navi)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if suggestions := d.Suggest("kaltxi", 1); len(suggestions) != 1 || suggestions[0].Distance != suggestConfusion {
+		t.Errorf("Suggest(kaltxi) = %v, want a distance of %v", suggestions, suggestConfusion)
+	}
+}
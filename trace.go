@@ -0,0 +1,107 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. trace.go explains how a word was deconjugated.
+package fwew_lib
+
+import (
+	"slices"
+	"strings"
+)
+
+// TraceOperation is the kind of a TraceStep
+type TraceOperation string
+
+const (
+	TracePrefix    TraceOperation = "prefix"    // a prefix was taken off
+	TraceInfix     TraceOperation = "infix"     // an infix was taken out
+	TraceSuffix    TraceOperation = "suffix"    // a suffix was taken off
+	TraceLenition  TraceOperation = "lenition"  // the lenition of the first letter was undone
+	TraceUnreef    TraceOperation = "unreef"    // a reef spelling was read as the forest one, see unreefFixes
+	TraceUnstrict  TraceOperation = "unstrict"  // a loose spelling was read as the proper one, see unstrictFixes
+	TraceIrregular TraceOperation = "irregular" // an irregular form was read as the dictionary word, see weirdNounSuffixes
+	TraceRespell   TraceOperation = "respell"   // a letter taken with the affix was put back, like the ' of fne'u
+	TraceDialect   TraceOperation = "dialect"   // the word was normalized before the lookup, see dialectCrunch
+)
+
+// TraceStep is one step the deconjugator took.
+// Before and After are the word before and after the step.
+// For TraceUnreef and TraceUnstrict steps of an affix, they are the spellings of Affix instead.
+type TraceStep struct {
+	Operation TraceOperation
+	Affix     string
+	Before    string
+	After     string
+}
+
+// Explanation is a word found by ExplainDeconjugation and the steps that led to it
+type Explanation struct {
+	Word  Word
+	Steps []TraceStep
+}
+
+// ExplainDeconjugation deconjugates a single Na'vi word like SearchFromNavi does,
+// and tells for every word found which steps led from the query to it.
+// The steps are in the order they were taken, from the outermost affix in.
+func (d *Dictionary) ExplainDeconjugation(query string, strict bool, allowReef bool) (explanations []Explanation) {
+	query = clean(query)
+	if len(query) == 0 || len([]rune(query)) > 50 {
+		return
+	}
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	dict := d.naviDict(strict, allowReef)
+	found := map[string]bool{}
+	// The word itself takes no steps
+	for _, word := range (*dict)[query] {
+		found[traceKey(word)] = true
+		explanations = append(explanations, Explanation{Word: word, Steps: []TraceStep{}})
+	}
+
+	results, traces := d.testDeconjugations(dict, query, strict, allowReef, strings.Contains(query, "ä"), true)
+	for _, word := range results {
+		if !found[traceKey(word)] {
+			explanations = append(explanations, Explanation{Word: word, Steps: traces[traceKey(word)]})
+		}
+	}
+	return
+}
+
+// Words with the same ID can be found with different affixes
+func traceKey(word Word) string {
+	return word.ID + "|" + strings.Join(word.Affixes.Prefix, "-") + "|" + strings.Join(word.Affixes.Infix, "-") +
+		"|" + strings.Join(word.Affixes.Suffix, "-") + "|" + strings.Join(word.Affixes.Lenition, "-")
+}
+
+// Add a step to the trace, if the candidate is traced
+func (c *ConjugationCandidate) trace(operation TraceOperation, affix string, before string, after string) {
+	if c.Trace != nil {
+		c.Trace = append(slices.Clip(c.Trace), TraceStep{operation, affix, before, after})
+	}
+}
+
+// Add the step of taking the affix off the word, the candidate's word is the one after it.
+// The reef and loose spellings read as the affix, see readFix, are traced first.
+func (c *ConjugationCandidate) traceFix(operation TraceOperation, spelling string, before string, strict bool, allowReef bool) {
+	if c.Trace == nil {
+		return
+	}
+	fix, steps, _ := readFix(spelling, strict, allowReef)
+	for _, step := range steps {
+		c.trace(step.Operation, step.Affix, step.Before, step.After)
+	}
+	c.trace(operation, fix, before, c.Word)
+}
@@ -0,0 +1,100 @@
+package fwew_lib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplainDeconjugation(t *testing.T) {
+	words := []Word{}
+	for _, word := range conjugateTestWords() {
+		words = append(words, word)
+	}
+	// made up for the breakdown of ayfnetsyìpìltsyìpvi
+	words = append(words, testWords(
+		testDictRow("14", "tsyìpìltsyìpvi", "t͡sjɪ.pɪl.ˈt͡sjɪp.vi", "NULL", "n.", "3", "tsyì-pìl-tsyìp-vi", "NULL", "twister"),
+	)...)
+	d := NewDictionary(NewMemorySource(words))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query     string
+		strict    bool
+		allowReef bool
+		navi      string
+		want      []TraceStep
+	}{
+		{"tute", true, false, "tute", []TraceStep{}},
+		{"aysutet", true, false, "tute", []TraceStep{
+			{TracePrefix, "ay", "aysutet", "sutet"},
+			{TraceLenition, "t→s", "sutet", "tutet"},
+			{TraceSuffix, "t", "tutet", "tute"},
+		}},
+		{"tolaron", true, false, "taron", []TraceStep{
+			{TraceInfix, "ol", "tolaron", "taron"},
+		}},
+		{"sutetsyip", false, false, "tute", []TraceStep{
+			{TraceUnstrict, "tsyìp", "tsyip", "tsyìp"},
+			{TraceSuffix, "tsyìp", "sutetsyip", "sute"},
+			{TraceLenition, "t→s", "sute", "tute"},
+		}},
+		{"tsatsmukane", false, true, "tsmukan", []TraceStep{
+			{TracePrefix, "tsa", "tsatsmukane", "tsmukane"},
+			{TraceUnreef, "ä", "e", "ä"},
+			{TraceSuffix, "ä", "tsmukane", "tsmukan"},
+		}},
+		{"zenatseke", true, false, "zenke", []TraceStep{
+			{TraceInfix, "ats", "zenatseke", "zeneke"},
+			{TraceIrregular, "", "zeneke", "zenke"},
+		}},
+		{"tseyä", true, false, "tsaw", []TraceStep{
+			{TraceSuffix, "yä", "tseyä", "tse"},
+			{TraceIrregular, "", "tse", "tsaw"},
+		}},
+		{"fayeylanìle", false, true, "'eylan", []TraceStep{
+			{TracePrefix, "fay", "fayeylanìle", "eylanìle"},
+			{TraceLenition, "'e→e", "eylanìle", "'eylanìle"},
+			{TraceUnreef, "ìlä", "ìle", "ìlä"},
+			{TraceSuffix, "ìlä", "'eylanìle", "'eylan"},
+		}},
+		{"peylan", true, false, "'eylan", []TraceStep{
+			{TracePrefix, "pe", "peylan", "ylan"},
+			{TraceRespell, "", "ylan", "eylan"},
+			{TraceLenition, "'e→e", "eylan", "'eylan"},
+		}},
+		{"peylan", false, true, "'eylan", []TraceStep{
+			{TracePrefix, "pe", "peylan", "ylan"},
+			{TraceRespell, "", "ylan", "eylan"},
+			{TraceLenition, "'e→e", "eylan", "'eylan"},
+		}},
+		{"ayfnetsyìpìltsyìpvi", true, false, "tsyìpìltsyìpvi", []TraceStep{
+			{TracePrefix, "ay", "ayfnetsyìpìltsyìpvi", "fnetsyìpìltsyìpvi"},
+			{TracePrefix, "fne", "fnetsyìpìltsyìpvi", "tsyìpìltsyìpvi"},
+		}},
+	}
+
+	for _, tt := range tests {
+		found := false
+		for _, explanation := range d.ExplainDeconjugation(tt.query, tt.strict, tt.allowReef) {
+			if explanation.Word.Navi != tt.navi {
+				continue
+			}
+			found = true
+			if !reflect.DeepEqual(explanation.Steps, tt.want) {
+				t.Errorf("ExplainDeconjugation(%s) steps to %s = %+v, want %+v", tt.query, tt.navi, explanation.Steps, tt.want)
+			}
+		}
+		if !found {
+			t.Errorf("ExplainDeconjugation(%s) didn't find %s", tt.query, tt.navi)
+		}
+	}
+
+	// Only ExplainDeconjugation traces
+	for _, candidate := range d.Deconjugate("aysutet", true, false) {
+		if candidate.Trace != nil {
+			t.Errorf("Deconjugate(aysutet) traced %s: %+v", candidate.Word, candidate.Trace)
+		}
+	}
+}
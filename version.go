@@ -15,13 +15,18 @@
 // Package fwew_lib contains all the things. version.go handles program version.
 package fwew_lib
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+)
 
 type version struct {
 	Major, Minor, Patch int
 	Label               string
 	Name                string
-	DictBuild           string
+	// The hash of the dictionary, it changes when the dictionary is watched or downloaded.
+	// Read it with DictBuild() then.
+	DictBuild string
 }
 
 // Version is a printable version struct containing program version information
@@ -32,11 +37,24 @@ var Version = version{
 	"",
 }
 
+// Guards Version.DictBuild
+var versionLock sync.RWMutex
+
 func init() {
-	file := FindDictionaryFile()
-	if file != "" {
-		Version.DictBuild = SHA1Hash(file)
-	}
+	setDictBuild(dictBuild())
+}
+
+// DictBuild is Version.DictBuild, safe to read while the dictionary is watched or downloaded
+func DictBuild() string {
+	versionLock.RLock()
+	defer versionLock.RUnlock()
+	return Version.DictBuild
+}
+
+func setDictBuild(build string) {
+	versionLock.Lock()
+	defer versionLock.Unlock()
+	Version.DictBuild = build
 }
 
 func (v version) String() string {
@@ -0,0 +1,168 @@
+//	This file is part of Fwew.
+//	Fwew is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+//	Fwew is distributed in the hope that it will be useful,
+//	but WITHOUT ANY WARRANTY; without even implied warranty of
+//	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//	GNU General Public License for more details.
+//
+//	You should have received a copy of the GNU General Public License
+//	along with Fwew.  If not, see http://gnu.org/licenses/
+
+// Package fwew_lib contains all the things. watcher.go reloads the dictionary when its file changes.
+package fwew_lib
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ReloadEvent tells what happened after a watched dictionary file changed
+type ReloadEvent struct {
+	// The dictionary file
+	Path string
+	// Short sha1 of the new file, like Version.DictBuild
+	Build string
+	// Number of entries before and after the reload
+	Before int
+	After  int
+	// Why the reload failed, nil if it worked.  The old dictionary is still in use then.
+	Err error
+}
+
+// Delta is the number of entries added (or removed, if negative)
+func (e ReloadEvent) Delta() int {
+	return e.After - e.Before
+}
+
+// What the watcher knows about the file
+type watchState struct {
+	path    string
+	modTime time.Time
+	size    int64
+	hash    string
+	// seen a change, waiting for the file to settle
+	changed bool
+	// already reported that the file is gone
+	missing bool
+}
+
+// Watch checks the dictionary file every interval and reloads the dictionary when it changed.
+// A change is a new modification time and a new sha1, so just touching the file does nothing.
+// The reload waits until the file stayed the same for one interval, so half written files are not read.
+//
+// A failed reload is tried again every interval, until it works or the file changes.
+//
+// report is called after every reload (or failed reload) on the watcher's goroutine, it may be nil.
+// Watching stops when ctx is done.  Only dictionaries reading from a FileSource can be watched.
+func (d *Dictionary) Watch(ctx context.Context, interval time.Duration, report func(event ReloadEvent)) error {
+	if interval <= 0 {
+		return InvalidInterval.wrap(fmt.Errorf("%v", interval))
+	}
+	source, ok := d.Source().(*FileSource)
+	if !ok {
+		return WatchNotSupported
+	}
+
+	path, err := source.file()
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	hash, err := fileHash(path)
+	if err != nil {
+		return err
+	}
+
+	state := watchState{
+		path:    path,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		hash:    hash,
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				event, reloaded := d.checkFile(source, &state)
+				if reloaded && report != nil {
+					report(event)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// checkFile looks at the file once and reloads, if needed
+func (d *Dictionary) checkFile(source *FileSource, state *watchState) (event ReloadEvent, reloaded bool) {
+	path, err := source.file()
+	var info os.FileInfo
+	if err == nil {
+		info, err = os.Stat(path)
+	}
+	if err != nil {
+		// only tell once
+		if state.missing {
+			return
+		}
+		state.missing = true
+		count := d.GetDictSizeSimple()
+		return ReloadEvent{Path: path, Before: count, After: count, Err: err}, true
+	}
+
+	if state.missing || path != state.path || !info.ModTime().Equal(state.modTime) || info.Size() != state.size {
+		state.missing = false
+		state.path = path
+		state.modTime = info.ModTime()
+		state.size = info.Size()
+		state.changed = true
+		return
+	}
+	if !state.changed {
+		return
+	}
+	state.changed = false
+
+	event = ReloadEvent{Path: path, Before: d.GetDictSizeSimple()}
+	event.Build, event.Err = fileHash(path)
+	if event.Err != nil {
+		event.After = event.Before
+		return event, true
+	}
+	if event.Build == state.hash {
+		return
+	}
+
+	d.reloadLock.Lock()
+	event.Err = d.reload()
+	d.reloadLock.Unlock()
+	event.After = d.GetDictSizeSimple()
+
+	if event.Err != nil {
+		// try again next time
+		state.changed = true
+		return event, true
+	}
+	state.hash = event.Build
+	if d == defaultDictionary {
+		setDictBuild(event.Build)
+		PhonemeDistros()
+	}
+
+	return event, true
+}
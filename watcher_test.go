@@ -0,0 +1,161 @@
+package fwew_lib
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDictionaryWatch(t *testing.T) {
+	rows := []string{
+		testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"),
+	}
+	path := writeTestDict(t, rows...)
+
+	d := NewDictionary(NewFileSource(path))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan ReloadEvent, 10)
+	if err := d.Watch(ctx, 5*time.Millisecond, func(event ReloadEvent) { events <- event }); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	next := func() ReloadEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("no reload reported")
+		}
+		return ReloadEvent{}
+	}
+
+	// write the file again with a new modification time
+	rewrite := func(content []byte, when time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, when, when); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// two new words
+	rows = append(rows,
+		testDictRow("2", "kaltxì", "kal.ˈtʼɪ", "NULL", "intj.", "2", "kal-txì", "NULL", "hello"),
+		testDictRow("3", "tsmukan", "ˈt͡smu.kan", "NULL", "n.", "1", "tsmu-kan", "NULL", "brother"),
+	)
+	content, _ := os.ReadFile(writeTestDict(t, rows...))
+	rewrite(content, time.Now().Add(time.Minute))
+
+	event := next()
+	if event.Err != nil || event.Before != 1 || event.After != 3 || event.Delta() != 2 || event.Path != path {
+		t.Errorf("reload event = %+v", event)
+	}
+	if want, _ := fileHash(path); event.Build != want {
+		t.Errorf("reload event Build = %q, want %q", event.Build, want)
+	}
+	if got := d.TranslateToNaviHash("hello", "en"); len(got) != 1 || len(got[0]) != 2 {
+		t.Errorf("TranslateToNaviHash() after reload = %v", got)
+	}
+
+	// only touching it does nothing, removing a word is noticed
+	rewrite(content, time.Now().Add(2*time.Minute))
+	content, _ = os.ReadFile(writeTestDict(t, rows[:2]...))
+	rewrite(content, time.Now().Add(3*time.Minute))
+
+	event = next()
+	if event.Err != nil || event.Before != 3 || event.After != 2 || event.Delta() != -1 {
+		t.Errorf("reload event = %+v", event)
+	}
+
+	// a missing file is a failure and the dictionary stays as it is
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	event = next()
+	if !errors.Is(event.Err, os.ErrNotExist) || event.Delta() != 0 {
+		t.Errorf("reload event for a missing file = %+v", event)
+	}
+	if got := d.GetDictSizeSimple(); got != 2 {
+		t.Errorf("GetDictSizeSimple() after the file is gone = %d, want 2", got)
+	}
+
+	// and it comes back
+	content, _ = os.ReadFile(writeTestDict(t, rows...))
+	rewrite(content, time.Now().Add(4*time.Minute))
+	event = next()
+	if event.Err != nil || event.Before != 2 || event.After != 3 {
+		t.Errorf("reload event = %+v", event)
+	}
+
+	// nothing after the watcher is stopped
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	content, _ = os.ReadFile(writeTestDict(t, rows[:1]...))
+	rewrite(content, time.Now().Add(5*time.Minute))
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case event := <-events:
+		t.Errorf("reload after stopping = %+v", event)
+	default:
+	}
+}
+
+func TestDictionaryWatchNotSupported(t *testing.T) {
+	d := NewDictionary(NewMemorySource(nil))
+	if err := d.Watch(context.Background(), time.Second, nil); !errors.Is(err, WatchNotSupported) {
+		t.Errorf("Watch() error = %v, want WatchNotSupported", err)
+	}
+}
+
+func TestDictionaryWatchInterval(t *testing.T) {
+	d := NewDictionary(NewFileSource(writeTestDict(t)))
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := d.Watch(context.Background(), interval, nil); !errors.Is(err, InvalidInterval) {
+			t.Errorf("Watch(%v) error = %v, want InvalidInterval", interval, err)
+		}
+	}
+}
+
+// A failed reload is tried again, even if the file stays the same
+func TestDictionaryWatchRetry(t *testing.T) {
+	path := writeTestDict(t, testDictRow("1", "tute", "ˈtu.tɛ", "NULL", "n.", "1", "tu-te", "NULL", "person"))
+	d := NewDictionary(NewFileSource(path))
+	if err := d.Load(); err != nil {
+		t.Fatal(err)
+	}
+	source := d.Source().(*FileSource)
+	hash, _ := fileHash(path)
+	state := watchState{path: path, hash: hash}
+
+	// a line too long for the scanner, so the hash works but reading fails
+	long := testDictHeader + "\n" + strings.Repeat("x", 100000) + "\n"
+	if err := os.WriteFile(path, []byte(long), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, reloaded := d.checkFile(source, &state); reloaded {
+		t.Fatal("reloaded before the file settled")
+	}
+	for i := 0; i < 2; i++ {
+		event, reloaded := d.checkFile(source, &state)
+		if !reloaded || event.Err == nil {
+			t.Fatalf("check %d = %+v, %v, want a failed reload", i, event, reloaded)
+		}
+	}
+	if state.hash != hash {
+		t.Errorf("the hash of the broken file was kept")
+	}
+	if got := d.GetDictSizeSimple(); got != 1 {
+		t.Errorf("GetDictSizeSimple() after failed reloads = %d, want 1", got)
+	}
+}
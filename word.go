@@ -17,37 +17,29 @@ package fwew_lib
 
 import (
 	"fmt"
-	"reflect"
+	"maps"
+	"slices"
 	"strings"
 )
 
 // Word is a struct that contains all the data about a given word
 type Word struct {
-	ID             string
-	Navi           string
-	IPA            string
-	InfixLocations string
-	PartOfSpeech   string
-	Source         string
-	Stressed       string
-	Syllables      string
-	InfixDots      string
-	DE             string
-	EN             string
-	ES             string
-	ET             string
-	FR             string
-	HU             string
-	IT             string
-	KO             string
-	NL             string
-	PL             string
-	PT             string
-	RU             string
-	SV             string
-	TR             string
-	UK             string
-	Affixes        affix
+	ID             string `json:"ID"`
+	Navi           string `json:"Navi"`
+	IPA            string `json:"IPA"`
+	InfixLocations string `json:"InfixLocations"`
+	PartOfSpeech   string `json:"PartOfSpeech"`
+	Source         string `json:"Source"`
+	Stressed       string `json:"Stressed"`
+	Syllables      string `json:"Syllables"`
+	InfixDots      string `json:"InfixDots"`
+	// Definitions by language code, see Languages()
+	Definitions map[string]string `json:"Definitions"`
+	// For definitions taken from another language because there is none in this one, that language.
+	// e.g. {"uk": "ru"}, see FallbackChain and DefinitionFrom
+	Fallbacks map[string]string `json:"Fallbacks,omitempty"`
+	// Always written as five arrays, see json.go
+	Affixes affix `json:"Affixes"`
 }
 
 // affixes has its own type, so it is automatically copied :)
@@ -69,6 +61,11 @@ func addAffixes(a affix, z affix) (w affix) {
 }
 
 func (w Word) String() string {
+	definitions := ""
+	for _, code := range definitionCodes(w.Definitions) {
+		definitions += fmt.Sprintf("%s: %s\n", strings.ToUpper(code), w.Definitions[code])
+	}
+
 	// this string only doesn't get translated or called from Text() because they're var names
 	return fmt.Sprintf(""+
 		"Id: %s\n"+
@@ -80,21 +77,7 @@ func (w Word) String() string {
 		"Stressed: %s\n"+
 		"Syllables: %s\n"+
 		"InfixDots: %s\n"+
-		"DE: %s\n"+
-		"EN: %s\n"+
-		"ES: %s\n"+
-		"ET: %s\n"+
-		"FR: %s\n"+
-		"HU: %s\n"+
-		"IT: %s\n"+
-		"KO: %s\n"+
-		"NL: %s\n"+
-		"PL: %s\n"+
-		"PT: %s\n"+
-		"RU: %s\n"+
-		"SV: %s\n"+
-		"TR: %s\n"+
-		"UK: %s\n"+
+		"%s"+
 		"Affixes: %v\n",
 		w.ID,
 		w.Navi,
@@ -105,25 +88,44 @@ func (w Word) String() string {
 		w.Stressed,
 		w.Syllables,
 		w.InfixDots,
-		w.DE,
-		w.EN,
-		w.ES,
-		w.ET,
-		w.FR,
-		w.HU,
-		w.IT,
-		w.KO,
-		w.NL,
-		w.PL,
-		w.PT,
-		w.RU,
-		w.SV,
-		w.TR,
-		w.UK,
+		definitions,
 		w.Affixes,
 	)
 }
 
+// Definition is the definition in the given language, empty if there is none
+func (w *Word) Definition(langCode string) string {
+	return w.Definitions[langCode]
+}
+
+// DefinitionFrom is the definition to show for the given language, and the language it really is in.
+// If there is no definition in that language, it is the one of the first language of FallbackChain that has one.
+func (w *Word) DefinitionFrom(langCode string) (definition string, from string) {
+	if from, ok := w.Fallbacks[langCode]; ok {
+		return w.Definitions[langCode], from
+	}
+	for _, code := range FallbackChain(langCode) {
+		if definition, ok := w.Definitions[code]; ok && !NullDef(definition) {
+			return definition, code
+		}
+	}
+	return w.Definitions[langCode], langCode
+}
+
+// SetDefinition sets the definition in the given language.
+// The definitions are copied first, so other copies of the Word keep theirs.
+func (w *Word) SetDefinition(langCode string, definition string) {
+	definitions := make(map[string]string, len(w.Definitions)+1)
+	maps.Copy(definitions, w.Definitions)
+	definitions[langCode] = definition
+	w.Definitions = definitions
+
+	if _, ok := w.Fallbacks[langCode]; ok {
+		w.Fallbacks = maps.Clone(w.Fallbacks)
+		delete(w.Fallbacks, langCode)
+	}
+}
+
 // Make a simple word to show what query led to this word
 func simpleWord(name string) Word {
 	var word Word
@@ -143,21 +145,10 @@ func newWord(dataFields []string, order dictPos) Word {
 	word.Stressed = dataFields[order.stsField]
 	word.Syllables = dataFields[order.sylField]
 	word.InfixDots = dataFields[order.ifdField]
-	word.DE = dataFields[order.deField]
-	word.EN = dataFields[order.enField]
-	word.ES = dataFields[order.esField]
-	word.ET = dataFields[order.etField]
-	word.FR = dataFields[order.frField]
-	word.HU = dataFields[order.huField]
-	word.IT = dataFields[order.itField]
-	word.KO = dataFields[order.koField]
-	word.NL = dataFields[order.nlField]
-	word.PL = dataFields[order.plField]
-	word.PT = dataFields[order.ptField]
-	word.RU = dataFields[order.ruField]
-	word.SV = dataFields[order.svField]
-	word.TR = dataFields[order.trField]
-	word.UK = dataFields[order.ukField]
+	word.Definitions = make(map[string]string, len(order.definitions))
+	for _, column := range order.definitions {
+		word.Definitions[column.code] = dataFields[column.index]
+	}
 	return word
 }
 
@@ -167,6 +158,8 @@ func newWord(dataFields []string, order dictPos) Word {
 func (w *Word) CloneWordStruct() Word {
 	// Copy struct to new instance
 	nw := *w
+	nw.Definitions = maps.Clone(w.Definitions)
+	nw.Fallbacks = maps.Clone(w.Fallbacks)
 
 	// copy the arrays manually
 	copy(nw.Affixes.Prefix, w.Affixes.Prefix)
@@ -188,22 +181,18 @@ func (w *Word) Equals(other Word) bool {
 		w.Stressed == other.Stressed &&
 		w.Syllables == other.Syllables &&
 		w.InfixDots == other.InfixDots &&
-		w.DE == other.DE &&
-		w.EN == other.EN &&
-		w.ES == other.ES &&
-		w.ET == other.ET &&
-		w.FR == other.FR &&
-		w.HU == other.HU &&
-		w.IT == other.IT &&
-		w.KO == other.KO &&
-		w.NL == other.NL &&
-		w.PL == other.PL &&
-		w.PT == other.PT &&
-		w.RU == other.RU &&
-		w.SV == other.SV &&
-		w.TR == other.TR &&
-		w.UK == other.UK &&
-		reflect.DeepEqual(w.Affixes, other.Affixes)
+		maps.Equal(w.Definitions, other.Definitions) &&
+		maps.Equal(w.Fallbacks, other.Fallbacks) &&
+		w.Affixes.equals(other.Affixes)
+}
+
+// nil and empty lists are the same
+func (a affix) equals(other affix) bool {
+	return slices.Equal(a.Prefix, other.Prefix) &&
+		slices.Equal(a.Infix, other.Infix) &&
+		slices.Equal(a.Suffix, other.Suffix) &&
+		slices.Equal(a.Lenition, other.Lenition) &&
+		slices.Equal(a.Comment, other.Comment)
 }
 
 func (w *Word) SyllableCount() int {
@@ -297,40 +286,9 @@ func (w *Word) ToOutputLine(
 
 	output += pos + space
 
-	switch langCode {
-	case "de":
-		output += w.DE
-	case "en":
-		output += w.EN
-	case "es":
-		output += w.ES
-	case "et":
-		output += w.ET
-	case "fr":
-		output += w.FR
-	case "hu":
-		output += w.HU
-	case "it":
-		output += w.IT
-	case "ko":
-		output += w.KO
-	case "nl":
-		output += w.NL
-	case "pl":
-		output += w.PL
-	case "pt":
-		output += w.PT
-	case "ru":
-		output += w.RU
-	case "sv":
-		output += w.SV
-	case "tr":
-		output += w.TR
-	case "uk":
-		output += w.UK
-	default:
-		output += w.EN
-	}
+	// If there is no definition in that language, show the one of its fallback
+	definition, _ := w.DefinitionFrom(langCode)
+	output += definition
 
 	if reef {
 		reefy := ReefMe(w.IPA, false)
@@ -473,32 +431,24 @@ func (w *Word) doUnderline(input string, markdown bool) (string, error) {
 	return underlined, nil
 }
 
+// Where the definitions of one language are
+type dictColumn struct {
+	code  string
+	index int
+}
+
 // This holds the positions, how the fields are sorted (with dictV2 the fields can have any order)
 type dictPos struct {
-	idField  int // Database ID
-	navField int // Na'vi word
-	ipaField int // IPA data
-	infField int // Infix location data
-	posField int // Part of Speech data
-	srcField int // Source data
-	stsField int // Stressed syllable #
-	sylField int // syllable breakdown
-	ifdField int // dot-style infix data
-	deField  int // German definition
-	enField  int // English definition
-	esField  int // Spanish definition
-	etField  int // Estonian definition
-	frField  int // French definition
-	huField  int // Hungarian definition
-	itField  int // Italian definition
-	koField  int // Korean definition
-	nlField  int // Dutch definition
-	plField  int // Polish definition
-	ptField  int // Portuguese definition
-	ruField  int // Russian definition
-	svField  int // Swedish definition
-	trField  int // Turkish definition
-	ukField  int // Ukrainian definition
+	idField     int          // Database ID
+	navField    int          // Na'vi word
+	ipaField    int          // IPA data
+	infField    int          // Infix location data
+	posField    int          // Part of Speech data
+	srcField    int          // Source data
+	stsField    int          // Stressed syllable #
+	sylField    int          // syllable breakdown
+	ifdField    int          // dot-style infix data
+	definitions []dictColumn // every language column, see Dictionary.LanguageCodes
 }
 
 func readDictPos(headerFields []string) dictPos {
@@ -524,36 +474,10 @@ func readDictPos(headerFields []string) dictPos {
 			pos.sylField = i
 		case "infixDots":
 			pos.ifdField = i
-		case "de":
-			pos.deField = i
-		case "en":
-			pos.enField = i
-		case "es":
-			pos.esField = i
-		case "et":
-			pos.etField = i
-		case "fr":
-			pos.frField = i
-		case "hu":
-			pos.huField = i
-		case "it":
-			pos.itField = i
-		case "ko":
-			pos.koField = i
-		case "nl":
-			pos.nlField = i
-		case "pl":
-			pos.plField = i
-		case "pt":
-			pos.ptField = i
-		case "ru":
-			pos.ruField = i
-		case "sv":
-			pos.svField = i
-		case "tr":
-			pos.trField = i
-		case "uk":
-			pos.ukField = i
+		default:
+			if isLanguageCode(field) {
+				pos.definitions = append(pos.definitions, dictColumn{code: field, index: i})
+			}
 		}
 	}
 